@@ -0,0 +1,54 @@
+package jsonforms
+
+import (
+	"sync"
+)
+
+// ParseInput is a single UI schema and data schema pair to parse in a batch
+type ParseInput struct {
+	ID       string
+	UISchema []byte
+	Schema   []byte
+}
+
+// ParseResult holds the outcome of parsing one ParseInput
+type ParseResult struct {
+	ID  string
+	AST *AST
+	Err error
+}
+
+// ParseBatch parses many inputs using at most concurrency goroutines.
+// Results are returned in the same order as inputs and carry the input's ID.
+func ParseBatch(inputs []ParseInput, concurrency int) []ParseResult {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]ParseResult, len(inputs))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for range min(concurrency, len(inputs)) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range jobs {
+				ast, err := Parse(inputs[i].UISchema, inputs[i].Schema)
+				results[i] = ParseResult{ID: inputs[i].ID, AST: ast, Err: err}
+			}
+		}()
+	}
+
+	for i := range inputs {
+		jobs <- i
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
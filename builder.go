@@ -0,0 +1,179 @@
+package jsonforms
+
+// NewControl creates a Control bound to scope
+func NewControl(scope string) *Control {
+	return &Control{
+		BaseUISchemaElement: BaseUISchemaElement{Type: "Control"},
+		Scope:               scope,
+	}
+}
+
+// NewVerticalLayout creates a VerticalLayout containing children
+func NewVerticalLayout(children ...UISchemaElement) *VerticalLayout {
+	return &VerticalLayout{
+		BaseUISchemaElement: BaseUISchemaElement{Type: "VerticalLayout"},
+		Elements:            children,
+	}
+}
+
+// NewHorizontalLayout creates a HorizontalLayout containing children
+func NewHorizontalLayout(children ...UISchemaElement) *HorizontalLayout {
+	return &HorizontalLayout{
+		BaseUISchemaElement: BaseUISchemaElement{Type: "HorizontalLayout"},
+		Elements:            children,
+	}
+}
+
+// NewGroup creates a labeled Group containing children
+func NewGroup(label string, children ...UISchemaElement) *Group {
+	return &Group{
+		BaseUISchemaElement: BaseUISchemaElement{Type: "Group"},
+		Label:               label,
+		Elements:            children,
+	}
+}
+
+// NewCategorization creates a Categorization containing categories or nested categorizations
+func NewCategorization(children ...CategoryElement) *Categorization {
+	return &Categorization{
+		BaseUISchemaElement: BaseUISchemaElement{Type: "Categorization"},
+		Elements:            children,
+	}
+}
+
+// NewCategory creates a labeled Category containing children
+func NewCategory(label string, children ...UISchemaElement) *Category {
+	return &Category{
+		BaseUISchemaElement: BaseUISchemaElement{Type: "Category"},
+		Label:               label,
+		Elements:            children,
+	}
+}
+
+// NewLabel creates a Label displaying text
+func NewLabel(text string) *Label {
+	return &Label{
+		BaseUISchemaElement: BaseUISchemaElement{Type: "Label"},
+		Text:                text,
+	}
+}
+
+// NewRule creates a Rule applying effect when condition holds
+func NewRule(effect RuleEffect, condition Condition) *Rule {
+	return &Rule{Effect: effect, Condition: condition}
+}
+
+// NewLeafCondition creates a LEAF condition comparing the value at scope to expectedValue
+func NewLeafCondition(scope string, expectedValue any) *LeafCondition {
+	return &LeafCondition{Type: "LEAF", Scope: scope, ExpectedValue: expectedValue}
+}
+
+// NewSchemaBasedCondition creates a condition validating the value at scope against schema
+func NewSchemaBasedCondition(scope string, schema any) *SchemaBasedCondition {
+	return &SchemaBasedCondition{Scope: scope, Schema: schema}
+}
+
+// WithLabel sets the control's label and returns the control for chaining
+func (c *Control) WithLabel(label any) *Control {
+	c.Label = label
+
+	return c
+}
+
+// WithRule sets the element's rule and returns the element for chaining
+func (c *Control) WithRule(rule *Rule) *Control {
+	c.Rule = rule
+
+	return c
+}
+
+// WithOptions sets the element's options and returns the element for chaining
+func (c *Control) WithOptions(options map[string]any) *Control {
+	c.Options = options
+
+	return c
+}
+
+// WithRule sets the element's rule and returns the element for chaining
+func (v *VerticalLayout) WithRule(rule *Rule) *VerticalLayout {
+	v.Rule = rule
+
+	return v
+}
+
+// WithOptions sets the element's options and returns the element for chaining
+func (v *VerticalLayout) WithOptions(options map[string]any) *VerticalLayout {
+	v.Options = options
+
+	return v
+}
+
+// WithRule sets the element's rule and returns the element for chaining
+func (h *HorizontalLayout) WithRule(rule *Rule) *HorizontalLayout {
+	h.Rule = rule
+
+	return h
+}
+
+// WithOptions sets the element's options and returns the element for chaining
+func (h *HorizontalLayout) WithOptions(options map[string]any) *HorizontalLayout {
+	h.Options = options
+
+	return h
+}
+
+// WithRule sets the element's rule and returns the element for chaining
+func (g *Group) WithRule(rule *Rule) *Group {
+	g.Rule = rule
+
+	return g
+}
+
+// WithOptions sets the element's options and returns the element for chaining
+func (g *Group) WithOptions(options map[string]any) *Group {
+	g.Options = options
+
+	return g
+}
+
+// WithRule sets the element's rule and returns the element for chaining
+func (c *Categorization) WithRule(rule *Rule) *Categorization {
+	c.Rule = rule
+
+	return c
+}
+
+// WithOptions sets the element's options and returns the element for chaining
+func (c *Categorization) WithOptions(options map[string]any) *Categorization {
+	c.Options = options
+
+	return c
+}
+
+// WithRule sets the element's rule and returns the element for chaining
+func (c *Category) WithRule(rule *Rule) *Category {
+	c.Rule = rule
+
+	return c
+}
+
+// WithOptions sets the element's options and returns the element for chaining
+func (c *Category) WithOptions(options map[string]any) *Category {
+	c.Options = options
+
+	return c
+}
+
+// WithRule sets the element's rule and returns the element for chaining
+func (l *Label) WithRule(rule *Rule) *Label {
+	l.Rule = rule
+
+	return l
+}
+
+// WithOptions sets the element's options and returns the element for chaining
+func (l *Label) WithOptions(options map[string]any) *Label {
+	l.Options = options
+
+	return l
+}
@@ -0,0 +1,57 @@
+package jsonforms
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilder(t *testing.T) {
+	form := NewVerticalLayout(
+		NewControl("#/properties/name").WithLabel("Name"),
+		NewGroup("Contact",
+			NewControl("#/properties/subscribe"),
+			NewControl("#/properties/email").
+				WithOptions(map[string]any{"trim": true}).
+				WithRule(NewRule(RuleEffectSHOW, NewLeafCondition("#/properties/subscribe", true))),
+		),
+	)
+
+	group, ok := form.Elements[1].(*Group)
+	require.True(t, ok, "Expected Group, got %T", form.Elements[1])
+	assert.Equal(t, "Contact", group.Label)
+	assert.Len(t, group.Elements, 2)
+
+	data, err := json.Marshal(form)
+	require.NoError(t, err)
+
+	expected := `{
+		"type": "VerticalLayout",
+		"elements": [
+			{"type": "Control", "scope": "#/properties/name", "label": "Name"},
+			{
+				"type": "Group",
+				"label": "Contact",
+				"elements": [
+					{"type": "Control", "scope": "#/properties/subscribe"},
+					{
+						"type": "Control",
+						"scope": "#/properties/email",
+						"options": {"trim": true},
+						"rule": {
+							"effect": "SHOW",
+							"condition": {"type": "LEAF", "scope": "#/properties/subscribe", "expectedValue": true}
+						}
+					}
+				]
+			}
+		]
+	}`
+	assert.JSONEq(t, expected, string(data))
+
+	parsed, err := Parse(data, nil)
+	require.NoError(t, err)
+	assert.Equal(t, form, parsed.UISchema)
+}
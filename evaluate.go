@@ -0,0 +1,375 @@
+package jsonforms
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"unicode/utf8"
+)
+
+// Static errors for rule evaluation
+var (
+	ErrUnsupportedCondition = errors.New("condition type cannot be evaluated")
+)
+
+// Evaluate reports whether the rule's condition holds for the given form data. Relative condition
+// scopes are resolved against the data root "#", as the owning element is not known.
+func (r *Rule) Evaluate(data map[string]any) (bool, error) {
+	return evaluateCondition(r.Condition, nil, data)
+}
+
+// evaluateFor reports whether the rule holds for the element it is attached to, resolving
+// relative condition scopes against that element's scope
+func (r *Rule) evaluateFor(owner UISchemaElement, data map[string]any) (bool, error) {
+	return evaluateCondition(r.Condition, owner, data)
+}
+
+// evaluateCondition evaluates any supported condition against form data. Relative scopes are
+// resolved against the owner's scope, or against "#" when owner is nil.
+func evaluateCondition(condition Condition, owner UISchemaElement, data map[string]any) (bool, error) {
+	switch c := condition.(type) {
+	case nil:
+		return false, ErrRuleMissingCondition
+	case *LeafCondition:
+		value, ok := resolveData(data, resolveConditionScope(owner, c))
+		if !ok {
+			return false, nil
+		}
+
+		// An array expectedValue lists the accepted values; an equal array value still matches
+		if expected, isList := c.ExpectedValue.([]any); isList && containsValue(expected, value) {
+			return true, nil
+		}
+
+		return valuesEqual(value, c.ExpectedValue), nil
+	case *SchemaBasedCondition:
+		value, ok := resolveData(data, resolveConditionScope(owner, c))
+		if !ok && c.FailWhenUndefined != nil && *c.FailWhenUndefined {
+			return false, nil
+		}
+
+		return matchesSchema(value, ok, c.Schema), nil
+	case *AndCondition:
+		return evaluateAll(c.Conditions, owner, data)
+	case *OrCondition:
+		return evaluateAny(c.Conditions, owner, data)
+	default:
+		return false, fmt.Errorf("%w: %s", ErrUnsupportedCondition, condition.GetType())
+	}
+}
+
+// Evaluate reports whether every child condition holds. It stops at the first child that is
+// false or fails, so later children are not evaluated.
+func (a *AndCondition) Evaluate(data map[string]any) (bool, error) {
+	return evaluateAll(a.Conditions, nil, data)
+}
+
+func evaluateAll(conditions []Condition, owner UISchemaElement, data map[string]any) (bool, error) {
+	for _, child := range conditions {
+		result, err := evaluateCondition(child, owner, data)
+		if err != nil || !result {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// Evaluate reports whether any child condition holds. It stops at the first child that is
+// true or fails, so later children are not evaluated.
+func (o *OrCondition) Evaluate(data map[string]any) (bool, error) {
+	return evaluateAny(o.Conditions, nil, data)
+}
+
+func evaluateAny(conditions []Condition, owner UISchemaElement, data map[string]any) (bool, error) {
+	for _, child := range conditions {
+		result, err := evaluateCondition(child, owner, data)
+		if err != nil || result {
+			return result, err
+		}
+	}
+
+	return false, nil
+}
+
+// resolveData follows a scope such as "#/properties/address/properties/street" into form data.
+// Numeric segments index into arrays, e.g. "#/properties/items/0/properties/qty".
+func resolveData(data map[string]any, scope string) (any, bool) {
+	segments, ok := scopeSegments(scope)
+	if !ok {
+		return nil, false
+	}
+
+	var current any = data
+
+	for i := 0; i < len(segments); i++ {
+		if list, isList := current.([]any); isList {
+			index, err := strconv.Atoi(segments[i])
+			if err != nil || index < 0 || index >= len(list) {
+				return nil, false
+			}
+
+			current = list[index]
+
+			continue
+		}
+
+		if segments[i] != "properties" || i+1 >= len(segments) {
+			return nil, false
+		}
+
+		i++
+
+		node, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+
+		if current, ok = node[segments[i]]; !ok {
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+// valuesEqual compares decoded JSON values, treating numbers of different representations as equal
+func valuesEqual(a, b any) bool {
+	if x, ok := NumberAsFloat64(a); ok {
+		y, ok := NumberAsFloat64(b)
+
+		return ok && x == y
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// matchesSchema checks a value against the subset of JSON Schema used in rule conditions.
+// defined is false when the scope did not resolve to any value.
+func matchesSchema(value any, defined bool, schema any) bool {
+	switch s := schema.(type) {
+	case bool:
+		return s
+	case map[string]any:
+		return matchesSchemaKeywords(value, defined, s)
+	default:
+		return true
+	}
+}
+
+func matchesSchemaKeywords(value any, defined bool, schema map[string]any) bool {
+	if expected, ok := schema["const"]; ok && (!defined || !valuesEqual(value, expected)) {
+		return false
+	}
+
+	if enum, ok := schema["enum"].([]any); ok && (!defined || !containsValue(enum, value)) {
+		return false
+	}
+
+	if schemaType, ok := schema["type"]; ok && (!defined || !matchesType(value, schemaType)) {
+		return false
+	}
+
+	// Range, length and required keywords only constrain values of the matching type
+	if number, ok := NumberAsFloat64(value); ok && !matchesRange(number, schema) {
+		return false
+	}
+
+	if text, ok := value.(string); ok && !matchesString(text, schema) {
+		return false
+	}
+
+	if object, ok := value.(map[string]any); ok && !hasRequired(object, schema) {
+		return false
+	}
+
+	if negated, ok := schema["not"]; ok && matchesSchema(value, defined, negated) {
+		return false
+	}
+
+	return true
+}
+
+// hasRequired checks that an object contains every key listed in "required"
+func hasRequired(object map[string]any, schema map[string]any) bool {
+	required, _ := schema["required"].([]any)
+
+	for _, entry := range required {
+		name, ok := entry.(string)
+		if !ok {
+			continue
+		}
+
+		if _, ok := object[name]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchesRange checks a number against "minimum" and "maximum"
+func matchesRange(number float64, schema map[string]any) bool {
+	if minimum, ok := NumberAsFloat64(schema["minimum"]); ok && number < minimum {
+		return false
+	}
+
+	if maximum, ok := NumberAsFloat64(schema["maximum"]); ok && number > maximum {
+		return false
+	}
+
+	return true
+}
+
+// matchesString checks a string against "minLength", "maxLength" and "pattern"
+func matchesString(text string, schema map[string]any) bool {
+	if !matchesLength(text, schema) {
+		return false
+	}
+
+	if pattern, ok := schema["pattern"].(string); ok {
+		re, err := regexp.Compile(pattern)
+		if err != nil || !re.MatchString(text) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchesLength checks a string's character count against "minLength" and "maxLength"
+func matchesLength(text string, schema map[string]any) bool {
+	length := float64(utf8.RuneCountInString(text))
+
+	if minLength, ok := NumberAsFloat64(schema["minLength"]); ok && length < minLength {
+		return false
+	}
+
+	maxLength, ok := NumberAsFloat64(schema["maxLength"])
+
+	return !ok || length <= maxLength
+}
+
+// containsValue reports whether values contains value
+func containsValue(values []any, value any) bool {
+	for _, candidate := range values {
+		if valuesEqual(candidate, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchesType checks a value against a JSON Schema "type", which may be a string or an array of strings
+func matchesType(value any, schemaType any) bool {
+	switch t := schemaType.(type) {
+	case string:
+		return matchesTypeName(value, t)
+	case []any:
+		for _, entry := range t {
+			if name, ok := entry.(string); ok && matchesTypeName(value, name) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func matchesTypeName(value any, name string) bool {
+	switch name {
+	case "string":
+		_, ok := value.(string)
+
+		return ok
+	case "number":
+		_, ok := NumberAsFloat64(value)
+
+		return ok
+	case "integer":
+		_, ok := NumberAsInt64(value)
+
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+
+		return ok
+	case "object":
+		_, ok := value.(map[string]any)
+
+		return ok
+	case "array":
+		_, ok := value.([]any)
+
+		return ok
+	case "null":
+		return value == nil
+	default:
+		return false
+	}
+}
+
+// VisibilityState describes whether a control is currently shown and editable
+type VisibilityState struct {
+	Visible bool
+	Enabled bool
+}
+
+// ComputeVisibility evaluates rules against form data and returns the state of every control.
+// Hidden or disabled containers hide or disable everything inside them, and readonly controls are
+// never enabled. Relative condition scopes are resolved against the scope of the element owning the
+// rule. Rules whose conditions cannot be evaluated are ignored.
+func ComputeVisibility(root UISchemaElement, data map[string]any) map[*Control]VisibilityState {
+	states := map[*Control]VisibilityState{}
+	computeVisibility(root, data, VisibilityState{Visible: true, Enabled: true}, states)
+
+	return states
+}
+
+func computeVisibility(element UISchemaElement, data map[string]any, state VisibilityState, states map[*Control]VisibilityState) {
+	if element == nil {
+		return
+	}
+
+	for _, rule := range ElementRules(element) {
+		state = applyRule(element, rule, data, state)
+	}
+
+	if control, ok := element.(*Control); ok {
+		if control.IsReadonly() {
+			state.Enabled = false
+		}
+
+		states[control] = state
+	}
+
+	for _, child := range childEntries(element) {
+		computeVisibility(child.Element, data, state, states)
+	}
+}
+
+// applyRule narrows an inherited state by the effect of a rule attached to element
+func applyRule(element UISchemaElement, rule *Rule, data map[string]any, state VisibilityState) VisibilityState {
+	matched, err := rule.evaluateFor(element, data)
+	if err != nil {
+		return state
+	}
+
+	switch rule.Effect {
+	case RuleEffectSHOW:
+		state.Visible = state.Visible && matched
+	case RuleEffectHIDE:
+		state.Visible = state.Visible && !matched
+	case RuleEffectENABLE:
+		state.Enabled = state.Enabled && matched
+	case RuleEffectDISABLE:
+		state.Enabled = state.Enabled && !matched
+	case RuleEffectREQUIRE:
+		// Required-ness does not affect visibility; ValidateInstance enforces it
+	}
+
+	return state
+}
@@ -0,0 +1,301 @@
+package jsonforms
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeVisibility(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{
+				"type": "Control",
+				"scope": "#/properties/subscribe"
+			},
+			{
+				"type": "Control",
+				"scope": "#/properties/email",
+				"rule": {
+					"effect": "SHOW",
+					"condition": {
+						"scope": "#/properties/subscribe",
+						"schema": {"const": true}
+					}
+				}
+			},
+			{
+				"type": "Group",
+				"label": "Phone",
+				"rule": {
+					"effect": "DISABLE",
+					"condition": {
+						"type": "LEAF",
+						"scope": "#/properties/subscribe",
+						"expectedValue": false
+					}
+				},
+				"elements": [
+					{
+						"type": "Control",
+						"scope": "#/properties/phone"
+					}
+				]
+			}
+		]
+	}`)
+
+	result, err := Parse(uiSchema, nil)
+	require.NoError(t, err)
+
+	layout, ok := result.UISchema.(*VerticalLayout)
+	require.True(t, ok, "Expected VerticalLayout, got %T", result.UISchema)
+
+	subscribe, ok := layout.Elements[0].(*Control)
+	require.True(t, ok, "Expected Control, got %T", layout.Elements[0])
+
+	email, ok := layout.Elements[1].(*Control)
+	require.True(t, ok, "Expected Control, got %T", layout.Elements[1])
+
+	group, ok := layout.Elements[2].(*Group)
+	require.True(t, ok, "Expected Group, got %T", layout.Elements[2])
+
+	phone, ok := group.Elements[0].(*Control)
+	require.True(t, ok, "Expected Control, got %T", group.Elements[0])
+
+	states := ComputeVisibility(result.UISchema, map[string]any{"subscribe": false})
+
+	assert.Equal(t, VisibilityState{Visible: true, Enabled: true}, states[subscribe])
+	assert.Equal(t, VisibilityState{Visible: false, Enabled: true}, states[email])
+	assert.Equal(t, VisibilityState{Visible: true, Enabled: false}, states[phone])
+
+	states = ComputeVisibility(result.UISchema, map[string]any{"subscribe": true})
+
+	assert.Equal(t, VisibilityState{Visible: true, Enabled: true}, states[email])
+	assert.Equal(t, VisibilityState{Visible: true, Enabled: true}, states[phone])
+}
+
+func TestRuleEvaluateUnsupportedCondition(t *testing.T) {
+	rule := &Rule{Effect: RuleEffectSHOW, Condition: &CustomCondition{RawData: map[string]any{"type": "RANGE"}}}
+
+	_, err := rule.Evaluate(map[string]any{})
+	require.ErrorIs(t, err, ErrUnsupportedCondition)
+}
+
+func TestComputeVisibilityReadonly(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{
+				"type": "Control",
+				"scope": "#/properties/id",
+				"options": {"readonly": true},
+				"rule": {
+					"effect": "ENABLE",
+					"condition": {
+						"type": "LEAF",
+						"scope": "#/properties/editable",
+						"expectedValue": true
+					}
+				}
+			},
+			{
+				"type": "Control",
+				"scope": "#/properties/name"
+			}
+		]
+	}`)
+
+	result, err := Parse(uiSchema, nil)
+	require.NoError(t, err)
+
+	layout, ok := result.UISchema.(*VerticalLayout)
+	require.True(t, ok, "Expected VerticalLayout, got %T", result.UISchema)
+
+	id, ok := layout.Elements[0].(*Control)
+	require.True(t, ok, "Expected Control, got %T", layout.Elements[0])
+
+	name, ok := layout.Elements[1].(*Control)
+	require.True(t, ok, "Expected Control, got %T", layout.Elements[1])
+
+	assert.True(t, id.IsReadonly())
+	assert.False(t, name.IsReadonly())
+
+	states := ComputeVisibility(result.UISchema, map[string]any{"editable": true})
+
+	assert.Equal(t, VisibilityState{Visible: true, Enabled: false}, states[id])
+	assert.Equal(t, VisibilityState{Visible: true, Enabled: true}, states[name])
+}
+
+func TestSchemaBasedConditionMinimum(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "Control",
+		"scope": "#/properties/alcohol",
+		"rule": {
+			"effect": "SHOW",
+			"condition": {
+				"scope": "#/properties/age",
+				"schema": {"minimum": 18}
+			}
+		}
+	}`)
+
+	result, err := Parse(uiSchema, nil)
+	require.NoError(t, err)
+
+	rule := result.UISchema.GetRule()
+
+	matched, err := rule.Evaluate(map[string]any{"age": 21.0})
+	require.NoError(t, err)
+	assert.True(t, matched)
+
+	matched, err = rule.Evaluate(map[string]any{"age": 16.0})
+	require.NoError(t, err)
+	assert.False(t, matched)
+}
+
+func TestSchemaBasedConditionStringKeywords(t *testing.T) {
+	condition := &SchemaBasedCondition{
+		Scope:  "#/properties/code",
+		Schema: map[string]any{"minLength": 2.0, "maxLength": 4.0, "pattern": "^[A-Z]+$", "maximum": 10.0},
+	}
+	rule := &Rule{Effect: RuleEffectSHOW, Condition: condition}
+
+	for value, expected := range map[string]bool{"AB": true, "ABCD": true, "A": false, "ABCDE": false, "ab": false} {
+		matched, err := rule.Evaluate(map[string]any{"code": value})
+		require.NoError(t, err)
+		assert.Equal(t, expected, matched, value)
+	}
+}
+
+func TestLeafConditionExpectedValueList(t *testing.T) {
+	scalar := &Rule{Effect: RuleEffectSHOW, Condition: NewLeafCondition("#/properties/country", "NZ")}
+	list := &Rule{Effect: RuleEffectSHOW, Condition: NewLeafCondition("#/properties/country", []any{"AU", "NZ"})}
+
+	tests := []struct {
+		name string
+		rule *Rule
+		data map[string]any
+		want bool
+	}{
+		{name: "scalar match", rule: scalar, data: map[string]any{"country": "NZ"}, want: true},
+		{name: "scalar mismatch", rule: scalar, data: map[string]any{"country": "AU"}, want: false},
+		{name: "list member", rule: list, data: map[string]any{"country": "AU"}, want: true},
+		{name: "list non-member", rule: list, data: map[string]any{"country": "US"}, want: false},
+		{name: "list equal value", rule: list, data: map[string]any{"country": []any{"AU", "NZ"}}, want: true},
+		{name: "list undefined", rule: list, data: map[string]any{}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.rule.Evaluate(tt.data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEvaluateArrayDataPath(t *testing.T) {
+	data := map[string]any{
+		"items": []any{
+			map[string]any{"qty": 3.0},
+			map[string]any{"qty": 12.0},
+		},
+	}
+
+	leaf := NewRule(RuleEffectSHOW, NewLeafCondition("#/properties/items/1/properties/qty", 12))
+	matched, err := leaf.Evaluate(data)
+	require.NoError(t, err)
+	assert.True(t, matched)
+
+	failWhenUndefined := true
+	outOfRange := NewRule(RuleEffectSHOW, &SchemaBasedCondition{
+		Scope:             "#/properties/items/5/properties/qty",
+		Schema:            map[string]any{"not": map[string]any{}},
+		FailWhenUndefined: &failWhenUndefined,
+	})
+	matched, err = outOfRange.Evaluate(data)
+	require.NoError(t, err)
+	assert.False(t, matched)
+
+	lenient := NewRule(RuleEffectSHOW, NewSchemaBasedCondition("#/properties/items/5/properties/qty", map[string]any{}))
+	matched, err = lenient.Evaluate(data)
+	require.NoError(t, err)
+	assert.True(t, matched)
+}
+
+func TestSchemaBasedConditionRequiredAndNot(t *testing.T) {
+	required := NewRule(RuleEffectSHOW, NewSchemaBasedCondition("#/properties/address", map[string]any{
+		"required": []any{"street"},
+	}))
+
+	matched, err := required.Evaluate(map[string]any{"address": map[string]any{"street": "Main St"}})
+	require.NoError(t, err)
+	assert.True(t, matched)
+
+	matched, err = required.Evaluate(map[string]any{"address": map[string]any{"city": "Springfield"}})
+	require.NoError(t, err)
+	assert.False(t, matched)
+
+	not := NewRule(RuleEffectSHOW, NewSchemaBasedCondition("#/properties/plan", map[string]any{
+		"not": map[string]any{"const": "free"},
+	}))
+
+	matched, err = not.Evaluate(map[string]any{"plan": "pro"})
+	require.NoError(t, err)
+	assert.True(t, matched)
+
+	matched, err = not.Evaluate(map[string]any{"plan": "free"})
+	require.NoError(t, err)
+	assert.False(t, matched)
+}
+
+func TestAndOrConditionShortCircuit(t *testing.T) {
+	data := map[string]any{"subscribe": true}
+	matching := NewLeafCondition("#/properties/subscribe", true)
+	failing := NewLeafCondition("#/properties/subscribe", false)
+
+	// Evaluating the sentinel child always fails, so reaching it surfaces an error
+	sentinel := &CustomCondition{RawData: map[string]any{"type": "SENTINEL"}}
+
+	and := &AndCondition{Type: "AND", Conditions: []Condition{failing, sentinel}}
+	matched, err := and.Evaluate(data)
+	require.NoError(t, err)
+	assert.False(t, matched)
+
+	and = &AndCondition{Type: "AND", Conditions: []Condition{matching, sentinel}}
+	_, err = and.Evaluate(data)
+	require.ErrorIs(t, err, ErrUnsupportedCondition)
+
+	or := &OrCondition{Type: "OR", Conditions: []Condition{matching, sentinel}}
+	matched, err = or.Evaluate(data)
+	require.NoError(t, err)
+	assert.True(t, matched)
+
+	or = &OrCondition{Type: "OR", Conditions: []Condition{failing, sentinel}}
+	_, err = or.Evaluate(data)
+	require.ErrorIs(t, err, ErrUnsupportedCondition)
+}
+
+func TestComputeVisibilityRelativeScope(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "Control",
+		"scope": "#/properties/address",
+		"rule": {
+			"effect": "SHOW",
+			"condition": {"type": "LEAF", "scope": "properties/kind", "expectedValue": "home"}
+		}
+	}`), nil)
+	require.NoError(t, err)
+
+	control, ok := result.UISchema.(*Control)
+	require.True(t, ok, "Expected Control, got %T", result.UISchema)
+
+	states := ComputeVisibility(control, map[string]any{"address": map[string]any{"kind": "home"}})
+	assert.Equal(t, VisibilityState{Visible: true, Enabled: true}, states[control])
+
+	states = ComputeVisibility(control, map[string]any{"address": map[string]any{"kind": "work"}})
+	assert.Equal(t, VisibilityState{Visible: false, Enabled: true}, states[control])
+}
@@ -0,0 +1,315 @@
+package jsonforms
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Static errors for export functions
+var (
+	ErrMissingDataSchema = errors.New("AST has no data schema")
+)
+
+// propertyNode is an ordered tree of data schema properties referenced by controls
+type propertyNode struct {
+	names    []string
+	children map[string]*propertyNode
+}
+
+func (n *propertyNode) child(name string) *propertyNode {
+	if n.children == nil {
+		n.children = map[string]*propertyNode{}
+	}
+
+	if existing, ok := n.children[name]; ok {
+		return existing
+	}
+
+	node := &propertyNode{}
+	n.children[name] = node
+	n.names = append(n.names, name)
+
+	return node
+}
+
+// ToTypeScript generates a TypeScript interface named typeName from the data schema,
+// limited to properties bound to controls. Optional properties are marked with "?"
+// and enums become union types.
+func (ast *AST) ToTypeScript(typeName string) (string, error) {
+	if ast.Schema == nil {
+		return "", ErrMissingDataSchema
+	}
+
+	root := &propertyNode{}
+
+	_ = WalkWithPath(ast.UISchema, func(element UISchemaElement, _ string) error {
+		control, ok := element.(*Control)
+		if !ok {
+			return nil
+		}
+
+		names, ok := propertyPath(control.Scope)
+		if !ok {
+			return nil
+		}
+
+		node := root
+		for _, name := range names {
+			node = node.child(name)
+		}
+
+		return nil
+	})
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "export interface %s ", typeName)
+	writeTSObject(&sb, ast.Schema, root, 0)
+	sb.WriteString("\n")
+
+	return sb.String(), nil
+}
+
+// writeTSObject writes an object literal type containing the selected properties of schema
+func writeTSObject(sb *strings.Builder, schema any, node *propertyNode, depth int) {
+	indent := strings.Repeat("  ", depth+1)
+
+	sb.WriteString("{\n")
+
+	for _, name := range node.names {
+		property, _ := schemaProperty(schema, name)
+
+		optional := "?"
+		if schemaRequires(schema, name) {
+			optional = ""
+		}
+
+		fmt.Fprintf(sb, "%s%s%s: ", indent, tsPropertyName(name), optional)
+
+		if child := node.children[name]; len(child.names) > 0 {
+			writeTSObject(sb, property, child, depth+1)
+		} else {
+			sb.WriteString(tsType(property))
+		}
+
+		sb.WriteString(";\n")
+	}
+
+	sb.WriteString(strings.Repeat("  ", depth) + "}")
+}
+
+// tsPropertyName quotes property names that are not valid identifiers
+func tsPropertyName(name string) string {
+	for i, r := range name {
+		isLetter := r == '_' || r == '$' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		if !isLetter && (i == 0 || r < '0' || r > '9') {
+			quoted, _ := json.Marshal(name)
+
+			return string(quoted)
+		}
+	}
+
+	return name
+}
+
+// tsType maps a JSON Schema to a TypeScript type expression
+func tsType(schema any) string {
+	node, ok := schema.(map[string]any)
+	if !ok {
+		return "unknown"
+	}
+
+	if enum, ok := node["enum"].([]any); ok && len(enum) > 0 {
+		return tsLiterals(enum)
+	}
+
+	if constValue, ok := node["const"]; ok {
+		return tsLiterals([]any{constValue})
+	}
+
+	switch schemaType := node["type"].(type) {
+	case string:
+		return tsPrimitive(schemaType, node)
+	case []any:
+		types := make([]string, 0, len(schemaType))
+
+		for _, t := range schemaType {
+			if name, ok := t.(string); ok {
+				types = append(types, tsPrimitive(name, node))
+			}
+		}
+
+		return strings.Join(types, " | ")
+	default:
+		return "unknown"
+	}
+}
+
+// tsPrimitive maps a single JSON Schema type name to TypeScript
+func tsPrimitive(schemaType string, node map[string]any) string {
+	switch schemaType {
+	case "string":
+		return "string"
+	case "number", "integer":
+		return "number"
+	case "boolean":
+		return "boolean"
+	case "null":
+		return "null"
+	case "array":
+		item := tsType(node["items"])
+		if strings.Contains(item, " | ") {
+			item = "(" + item + ")"
+		}
+
+		return item + "[]"
+	case "object":
+		return "Record<string, unknown>"
+	default:
+		return "unknown"
+	}
+}
+
+// tsLiterals renders values as a union of TypeScript literal types
+func tsLiterals(values []any) string {
+	literals := make([]string, 0, len(values))
+
+	for _, value := range values {
+		literal, err := json.Marshal(value)
+		if err != nil {
+			return "unknown"
+		}
+
+		literals = append(literals, string(literal))
+	}
+
+	return strings.Join(literals, " | ")
+}
+
+// constraintKeywords are the JSON Schema keywords copied into RenderModel constraints
+var constraintKeywords = []string{
+	"minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "multipleOf",
+	"minLength", "maxLength", "pattern", "format", "minItems", "maxItems", "uniqueItems",
+}
+
+// RenderModel resolves every control against the data schema and returns a flat map keyed by scope.
+// Each entry holds the resolved "type", "label", "required" flag, "enum" options and "constraints",
+// so a renderer can draw the form without consulting the schema again.
+func (ast *AST) RenderModel() (map[string]any, error) {
+	if ast.Schema == nil {
+		return nil, ErrMissingDataSchema
+	}
+
+	model := map[string]any{}
+
+	err := WalkWithPath(ast.UISchema, func(element UISchemaElement, path string) error {
+		control, ok := element.(*Control)
+		if !ok {
+			return nil
+		}
+
+		sub, ok := ResolveScope(ast.Schema, control.Scope)
+		if !ok {
+			return &PathError{Path: path, Err: fmt.Errorf("%w: %s", ErrUnresolvableScope, control.Scope)}
+		}
+
+		node, _ := sub.(map[string]any)
+		dataType, _ := schemaType(sub)
+
+		entry := map[string]any{
+			"type":     dataType,
+			"label":    control.EffectiveLabel(ast.Schema),
+			"required": scopeRequired(ast.Schema, control.Scope),
+		}
+
+		if enum, ok := node["enum"].([]any); ok {
+			entry["enum"] = enum
+		}
+
+		constraints := map[string]any{}
+
+		for _, keyword := range constraintKeywords {
+			if value, ok := node[keyword]; ok {
+				constraints[keyword] = value
+			}
+		}
+
+		entry["constraints"] = constraints
+		model[control.Scope] = entry
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// ToDOT renders the element tree as a Graphviz digraph. Nodes are labeled with the element type
+// and its scope, label or text; edges point from containers to their children.
+func ToDOT(root UISchemaElement) string {
+	var sb strings.Builder
+
+	sb.WriteString("digraph UISchema {\n")
+	sb.WriteString("  node [shape=box];\n")
+
+	if root != nil {
+		next := 0
+		writeDOTNode(&sb, root, &next)
+	}
+
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
+
+// writeDOTNode writes a node and its subtree, returning the node's id
+func writeDOTNode(sb *strings.Builder, element UISchemaElement, next *int) string {
+	id := fmt.Sprintf("n%d", *next)
+	*next++
+
+	fmt.Fprintf(sb, "  %s [label=%s];\n", id, dotLabel(element))
+
+	for _, child := range childEntries(element) {
+		childID := writeDOTNode(sb, child.Element, next)
+		fmt.Fprintf(sb, "  %s -> %s;\n", id, childID)
+	}
+
+	return id
+}
+
+// dotEscaper escapes characters that are significant inside quoted DOT strings
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// dotLabel builds a quoted DOT label describing an element
+func dotLabel(element UISchemaElement) string {
+	parts := []string{element.GetType()}
+
+	switch e := element.(type) {
+	case *Control:
+		parts = append(parts, e.Scope)
+	case *ListWithDetail:
+		parts = append(parts, e.Scope)
+	case *Group:
+		parts = append(parts, e.Label)
+	case *Category:
+		parts = append(parts, e.Label)
+	case *Categorization:
+		if e.Label != nil {
+			parts = append(parts, *e.Label)
+		}
+	case *Label:
+		parts = append(parts, e.Text)
+	}
+
+	escaped := make([]string, len(parts))
+
+	for i, part := range parts {
+		escaped[i] = dotEscaper.Replace(part)
+	}
+
+	return `"` + strings.Join(escaped, `\n`) + `"`
+}
@@ -0,0 +1,160 @@
+package jsonforms
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToTypeScript(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{
+				"type": "Control",
+				"scope": "#/properties/name"
+			},
+			{
+				"type": "Control",
+				"scope": "#/properties/subscribe"
+			},
+			{
+				"type": "Control",
+				"scope": "#/properties/plan"
+			}
+		]
+	}`)
+
+	schema := []byte(`{
+		"type": "object",
+		"required": ["name", "plan"],
+		"properties": {
+			"name": {"type": "string"},
+			"subscribe": {"type": "boolean"},
+			"plan": {"type": "string", "enum": ["free", "pro"]},
+			"internal": {"type": "string"}
+		}
+	}`)
+
+	result, err := Parse(uiSchema, schema)
+	require.NoError(t, err)
+
+	ts, err := result.ToTypeScript("Person")
+	require.NoError(t, err)
+
+	expected := `export interface Person {
+  name: string;
+  subscribe?: boolean;
+  plan: "free" | "pro";
+}
+`
+	assert.Equal(t, expected, ts)
+}
+
+func TestToTypeScriptWithoutSchema(t *testing.T) {
+	result, err := Parse([]byte(`{"type": "Control", "scope": "#/properties/name"}`), nil)
+	require.NoError(t, err)
+
+	_, err = result.ToTypeScript("Person")
+	require.ErrorIs(t, err, ErrMissingDataSchema)
+}
+
+func TestRenderModel(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{
+				"type": "Control",
+				"scope": "#/properties/name",
+				"label": "Full Name"
+			},
+			{
+				"type": "Control",
+				"scope": "#/properties/plan"
+			}
+		]
+	}`)
+
+	schema := []byte(`{
+		"type": "object",
+		"required": ["name"],
+		"properties": {
+			"name": {"type": "string", "minLength": 2, "maxLength": 40},
+			"plan": {"type": "string", "title": "Plan", "enum": ["free", "pro"]}
+		}
+	}`)
+
+	result, err := Parse(uiSchema, schema)
+	require.NoError(t, err)
+
+	model, err := result.RenderModel()
+	require.NoError(t, err)
+	require.Len(t, model, 2)
+
+	assert.Equal(t, map[string]any{
+		"type":        "string",
+		"label":       "Full Name",
+		"required":    true,
+		"constraints": map[string]any{"minLength": float64(2), "maxLength": float64(40)},
+	}, model["#/properties/name"])
+
+	assert.Equal(t, map[string]any{
+		"type":        "string",
+		"label":       "Plan",
+		"required":    false,
+		"enum":        []any{"free", "pro"},
+		"constraints": map[string]any{},
+	}, model["#/properties/plan"])
+}
+
+func TestRenderModelUnresolvableScope(t *testing.T) {
+	result, err := Parse([]byte(`{"type": "Control", "scope": "#/properties/missing"}`), personSchema)
+	require.NoError(t, err)
+
+	_, err = result.RenderModel()
+	require.ErrorIs(t, err, ErrUnresolvableScope)
+}
+
+func TestToDOT(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{
+				"type": "Group",
+				"label": "Personal Info",
+				"elements": [
+					{
+						"type": "Control",
+						"scope": "#/properties/name"
+					}
+				]
+			},
+			{
+				"type": "Notice",
+				"elements": [
+					{
+						"type": "Label",
+						"text": "Say \"hi\""
+					}
+				]
+			}
+		]
+	}`)
+
+	result, err := Parse(uiSchema, nil)
+	require.NoError(t, err)
+
+	dot := ToDOT(result.UISchema)
+
+	assert.Contains(t, dot, "digraph UISchema {")
+	assert.Contains(t, dot, `n0 [label="VerticalLayout"];`)
+	assert.Contains(t, dot, `n1 [label="Group\nPersonal Info"];`)
+	assert.Contains(t, dot, `n2 [label="Control\n#/properties/name"];`)
+	assert.Contains(t, dot, `n3 [label="Notice"];`)
+	assert.Contains(t, dot, `n4 [label="Label\nSay \"hi\""];`)
+	assert.Contains(t, dot, "n0 -> n1;")
+	assert.Contains(t, dot, "n1 -> n2;")
+	assert.Contains(t, dot, "n0 -> n3;")
+	assert.Contains(t, dot, "n3 -> n4;")
+}
@@ -0,0 +1,130 @@
+package jsonforms
+
+import (
+	"encoding/json"
+)
+
+// JSONSchema is a typed view of the data schema keywords JSON Forms relies on.
+// Keywords without a field are kept in Extra.
+type JSONSchema struct {
+	Type        any                    `json:"type,omitempty"` // A type name or an array of names
+	Title       string                 `json:"title,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Properties  map[string]*JSONSchema `json:"properties,omitempty"`
+	Items       *JSONSchema            `json:"items,omitempty"` // Tuple items arrays are kept in Extra
+	Required    []string               `json:"required,omitempty"`
+	Enum        []any                  `json:"enum,omitempty"`
+	Const       any                    `json:"const,omitempty"`
+	Default     any                    `json:"default,omitempty"`
+	Format      string                 `json:"format,omitempty"`
+	Pattern     string                 `json:"pattern,omitempty"`
+	Minimum     *float64               `json:"minimum,omitempty"`
+	Maximum     *float64               `json:"maximum,omitempty"`
+	MinLength   *int                   `json:"minLength,omitempty"`
+	MaxLength   *int                   `json:"maxLength,omitempty"`
+	OneOf       []*JSONSchema          `json:"oneOf,omitempty"`
+	Boolean     *bool                  `json:"-"` // Set when the schema is the literal true or false
+	Extra       map[string]any         `json:"-"` // Unrecognized keywords
+}
+
+// jsonSchemaKeywords are the keywords decoded into JSONSchema fields
+var jsonSchemaKeywords = map[string]bool{
+	"type":        true,
+	"title":       true,
+	"description": true,
+	"properties":  true,
+	"items":       true,
+	"required":    true,
+	"enum":        true,
+	"const":       true,
+	"default":     true,
+	"format":      true,
+	"pattern":     true,
+	"minimum":     true,
+	"maximum":     true,
+	"minLength":   true,
+	"maxLength":   true,
+	"oneOf":       true,
+}
+
+// UnmarshalJSON decodes known keywords into fields and the rest into Extra. A known keyword whose
+// value does not fit its field, such as tuple "items" or a draft-03 boolean "required", is kept
+// in Extra as well.
+func (s *JSONSchema) UnmarshalJSON(data []byte) error {
+	var boolean bool
+	if err := json.Unmarshal(data, &boolean); err == nil {
+		*s = JSONSchema{Boolean: &boolean}
+
+		return nil
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	type plain JSONSchema
+
+	// fits reports whether a single keyword decodes into its field
+	fits := func(key string, value json.RawMessage) bool {
+		keyword, err := json.Marshal(map[string]json.RawMessage{key: value})
+		if err != nil {
+			return false
+		}
+
+		var probe plain
+
+		return json.Unmarshal(keyword, &probe) == nil
+	}
+
+	known := map[string]json.RawMessage{}
+	extra := map[string]any{}
+
+	for key, value := range raw {
+		if jsonSchemaKeywords[key] && fits(key, value) {
+			known[key] = value
+
+			continue
+		}
+
+		var decoded any
+		if err := json.Unmarshal(value, &decoded); err != nil {
+			return err
+		}
+
+		extra[key] = decoded
+	}
+
+	var typed plain
+
+	knownJSON, err := json.Marshal(known)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(knownJSON, &typed); err != nil {
+		return err
+	}
+
+	*s = JSONSchema(typed)
+	if len(extra) > 0 {
+		s.Extra = extra
+	}
+
+	return nil
+}
+
+// decodeTypedSchema converts a raw data schema into its typed form
+func decodeTypedSchema(schema any) (*JSONSchema, error) {
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	var typed JSONSchema
+	if err := json.Unmarshal(data, &typed); err != nil {
+		return nil, err
+	}
+
+	return &typed, nil
+}
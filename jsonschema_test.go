@@ -0,0 +1,92 @@
+package jsonforms
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTypedSchema(t *testing.T) {
+	uiSchema := []byte(`{"type": "Control", "scope": "#/properties/name"}`)
+	schema := []byte(`{
+		"type": "object",
+		"required": ["name"],
+		"$id": "person",
+		"properties": {
+			"name": {"type": "string", "minLength": 1},
+			"age": {"type": ["integer", "null"], "minimum": 0},
+			"address": {
+				"type": "object",
+				"properties": {
+					"country": {"enum": ["NZ", "AU"], "default": "NZ"}
+				}
+			},
+			"tags": {"type": "array", "items": {"type": "string"}},
+			"pair": {"type": "array", "items": [{"type": "string"}, {"type": "number"}]},
+			"anything": true
+		}
+	}`)
+
+	result, err := Parse(uiSchema, schema)
+	require.NoError(t, err)
+	assert.Nil(t, result.TypedSchema)
+
+	result, err = ParseWithOptions(uiSchema, schema, ParseOptions{TypedSchema: true})
+	require.NoError(t, err)
+	require.NotNil(t, result.TypedSchema)
+	assert.NotNil(t, result.Schema)
+
+	typed := result.TypedSchema
+	assert.Equal(t, "object", typed.Type)
+	assert.Equal(t, []string{"name"}, typed.Required)
+	assert.Equal(t, map[string]any{"$id": "person"}, typed.Extra)
+
+	name := typed.Properties["name"]
+	require.NotNil(t, name)
+	require.NotNil(t, name.MinLength)
+	assert.Equal(t, 1, *name.MinLength)
+
+	age := typed.Properties["age"]
+	require.NotNil(t, age)
+	assert.Equal(t, []any{"integer", "null"}, age.Type)
+	require.NotNil(t, age.Minimum)
+	assert.InDelta(t, 0.0, *age.Minimum, 0)
+
+	country := typed.Properties["address"].Properties["country"]
+	require.NotNil(t, country)
+	assert.Equal(t, []any{"NZ", "AU"}, country.Enum)
+	assert.Equal(t, "NZ", country.Default)
+
+	assert.Equal(t, "string", typed.Properties["tags"].Items.Type)
+
+	pair := typed.Properties["pair"]
+	assert.Nil(t, pair.Items)
+	assert.Len(t, pair.Extra["items"], 2)
+
+	anything := typed.Properties["anything"]
+	require.NotNil(t, anything.Boolean)
+	assert.True(t, *anything.Boolean)
+}
+
+func TestParseTypedSchemaMistypedKeywords(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"properties": {
+			"name": {"type": "string", "required": true, "minLength": "1", "maxLength": 10}
+		}
+	}`)
+
+	result, err := ParseWithOptions([]byte(`{"type": "Control", "scope": "#/properties/name"}`), schema, ParseOptions{TypedSchema: true})
+	require.NoError(t, err)
+	require.NotNil(t, result.TypedSchema)
+
+	name := result.TypedSchema.Properties["name"]
+	require.NotNil(t, name)
+	assert.Equal(t, "string", name.Type)
+	assert.Nil(t, name.Required)
+	assert.Nil(t, name.MinLength)
+	require.NotNil(t, name.MaxLength)
+	assert.Equal(t, 10, *name.MaxLength)
+	assert.Equal(t, map[string]any{"required": true, "minLength": "1"}, name.Extra)
+}
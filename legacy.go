@@ -0,0 +1,91 @@
+package jsonforms
+
+import (
+	"fmt"
+)
+
+// ParseLegacyV2 parses a UI schema written for older JSON Forms releases by rewriting legacy keys
+// to their current form before the standard parse. The mapping is:
+//
+//   - "ref": "#/properties/x" on an element or condition becomes "scope": "#/properties/x"
+//   - "scope": {"$ref": "#/properties/x"} becomes "scope": "#/properties/x"
+//
+// Keys are rewritten in elements, control detail layouts and rule conditions. A current "scope"
+// takes precedence over "ref". The returned AST has no data schema.
+func ParseLegacyV2(data []byte) (*AST, error) {
+	p := &parser{}
+
+	var raw map[string]any
+	if err := p.decode(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse UI schema: invalid JSON: %w", err)
+	}
+
+	upgradeLegacyElement(raw)
+
+	uiSchema, err := p.parseUISchemaElement(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse UI schema: %w", err)
+	}
+
+	return &AST{UISchema: uiSchema}, nil
+}
+
+// upgradeLegacyElement rewrites legacy keys of an element and everything nested inside it
+func upgradeLegacyElement(element map[string]any) {
+	upgradeLegacyScope(element)
+
+	elements, _ := element["elements"].([]any)
+	for _, child := range elements {
+		if child, ok := child.(map[string]any); ok {
+			upgradeLegacyElement(child)
+		}
+	}
+
+	options, _ := element["options"].(map[string]any)
+	if detail, ok := options["detail"].(map[string]any); ok {
+		upgradeLegacyElement(detail)
+	}
+
+	if rule, ok := element["rule"].(map[string]any); ok {
+		upgradeLegacyCondition(rule["condition"])
+	}
+
+	rules, _ := element["rules"].([]any)
+	for _, rule := range rules {
+		if rule, ok := rule.(map[string]any); ok {
+			upgradeLegacyCondition(rule["condition"])
+		}
+	}
+}
+
+// upgradeLegacyCondition rewrites legacy scope keys of a condition and its nested conditions
+func upgradeLegacyCondition(data any) {
+	condition, ok := data.(map[string]any)
+	if !ok {
+		return
+	}
+
+	upgradeLegacyScope(condition)
+
+	conditions, _ := condition["conditions"].([]any)
+	for _, child := range conditions {
+		upgradeLegacyCondition(child)
+	}
+}
+
+// upgradeLegacyScope converts "ref" and {"$ref": ...} scopes to a plain "scope" string
+func upgradeLegacyScope(data map[string]any) {
+	if scope, ok := data["scope"].(map[string]any); ok {
+		if ref, ok := scope["$ref"].(string); ok {
+			data["scope"] = ref
+		}
+	}
+
+	if ref, ok := data["ref"].(string); ok {
+		if _, hasScope := data["scope"]; !hasScope {
+			data["scope"] = ref
+		}
+
+		delete(data, "ref")
+	}
+}
@@ -0,0 +1,45 @@
+package jsonforms
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLegacyV2(t *testing.T) {
+	result, err := ParseLegacyV2([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{"type": "Control", "ref": "#/properties/name"},
+			{
+				"type": "Control",
+				"scope": {"$ref": "#/properties/email"},
+				"rule": {
+					"effect": "SHOW",
+					"condition": {"type": "LEAF", "ref": "#/properties/subscribe", "expectedValue": true}
+				}
+			}
+		]
+	}`))
+	require.NoError(t, err)
+
+	layout, ok := result.UISchema.(*VerticalLayout)
+	require.True(t, ok, "Expected VerticalLayout, got %T", result.UISchema)
+
+	name, ok := layout.Elements[0].(*Control)
+	require.True(t, ok, "Expected Control, got %T", layout.Elements[0])
+	assert.Equal(t, "#/properties/name", name.Scope)
+	assert.Nil(t, name.Extra)
+
+	email, ok := layout.Elements[1].(*Control)
+	require.True(t, ok, "Expected Control, got %T", layout.Elements[1])
+	assert.Equal(t, "#/properties/email", email.Scope)
+
+	condition, ok := email.Rule.Condition.(*LeafCondition)
+	require.True(t, ok, "Expected LeafCondition, got %T", email.Rule.Condition)
+	assert.Equal(t, "#/properties/subscribe", condition.Scope)
+
+	_, err = ParseLegacyV2([]byte(`{"type": "Control"}`))
+	require.ErrorIs(t, err, ErrControlMissingScope)
+}
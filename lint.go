@@ -0,0 +1,161 @@
+package jsonforms
+
+import (
+	"fmt"
+	"slices"
+)
+
+// LintFinding describes a likely authoring mistake at an element path
+type LintFinding struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// LintRules flags rules that are structurally valid but cannot behave as intended:
+// unknown effects, missing conditions, empty AND/OR condition lists, and schema-based
+// conditions without a schema. A null expectedValue is a valid comparison and is not flagged.
+// Rules inside detail layouts are linted too, with paths such as "elements[0].detail.elements[1]".
+func LintRules(root UISchemaElement) []LintFinding {
+	var findings []LintFinding
+
+	_ = walkTree(root, "", func(element UISchemaElement, path string) error {
+		for _, rule := range ElementRules(element) {
+			findings = append(findings, lintRule(rule, path)...)
+		}
+
+		return nil
+	})
+
+	return findings
+}
+
+// lintRule checks a single rule's effect and condition tree
+func lintRule(rule *Rule, path string) []LintFinding {
+	var findings []LintFinding
+
+	if !rule.Effect.IsValid() {
+		findings = append(findings, LintFinding{Path: path, Message: fmt.Sprintf("invalid rule effect %q", rule.Effect)})
+	}
+
+	if rule.Condition == nil {
+		return append(findings, LintFinding{Path: path, Message: "rule has no condition"})
+	}
+
+	forEachCondition(rule.Condition, func(condition Condition) {
+		if message, ok := lintCondition(condition); ok {
+			findings = append(findings, LintFinding{Path: path, Message: message})
+		}
+	})
+
+	return findings
+}
+
+// lintCondition returns a message describing a problem with a single condition
+func lintCondition(condition Condition) (string, bool) {
+	switch c := condition.(type) {
+	case *AndCondition:
+		if len(c.Conditions) == 0 {
+			return "AND condition has no conditions", true
+		}
+	case *OrCondition:
+		if len(c.Conditions) == 0 {
+			return "OR condition has no conditions", true
+		}
+	case *SchemaBasedCondition:
+		if c.Schema == nil {
+			return fmt.Sprintf("SCHEMA_BASED condition on %s has no schema", c.Scope), true
+		}
+	}
+
+	return "", false
+}
+
+// controlVariants lists the options.variant values renderers support per property type
+var controlVariants = map[string][]string{
+	"number":  {"stepper"},
+	"integer": {"stepper"},
+}
+
+// LintOptions flags control options that do not fit the bound property in the data schema,
+// such as a toggle over a non-boolean property, a slider without schema bounds, or a variant the
+// property type does not support. Properties without a "type" are only checked for slider bounds.
+// Controls in detail layouts are checked against their array's item schema.
+// Returns nil when the AST has no data schema.
+func LintOptions(ast *AST) []LintFinding {
+	if ast == nil || ast.Schema == nil {
+		return nil
+	}
+
+	var findings []LintFinding
+
+	lintElementOptions(ast.UISchema, "", ast.Schema, &findings)
+
+	return findings
+}
+
+// lintElementOptions lints the controls under element against schema, switching to the array's
+// item schema for a detail layout
+func lintElementOptions(element UISchemaElement, path string, schema any, findings *[]LintFinding) {
+	if element == nil {
+		return
+	}
+
+	if control, ok := element.(*Control); ok {
+		*findings = append(*findings, lintControlOptions(control, path, schema)...)
+	}
+
+	for _, child := range childEntries(element) {
+		lintElementOptions(child.Element, childPath(path, child.Segment), schema, findings)
+	}
+
+	if scope := elementScope(element); scope != nil {
+		arraySchema, _ := ResolveScope(schema, *scope)
+		node, _ := arraySchema.(map[string]any)
+		lintElementOptions(detailOf(element), childPath(path, "detail"), node["items"], findings)
+	}
+}
+
+// lintControlOptions checks a single control's options against the property it is bound to
+func lintControlOptions(control *Control, path string, schema any) []LintFinding {
+	sub, ok := ResolveScope(schema, control.Scope)
+	if !ok {
+		return nil
+	}
+
+	var findings []LintFinding
+
+	// Type-based checks are skipped for untyped properties such as oneOf or $ref-only schemas
+	dataType, typed := schemaType(sub)
+
+	if control.IsToggle() && typed && dataType != "boolean" {
+		findings = append(findings, LintFinding{
+			Path:    path,
+			Message: fmt.Sprintf("toggle on %s requires a boolean property, got %s", control.Scope, dataType),
+		})
+	}
+
+	if control.IsSlider() && !hasRange(sub) {
+		findings = append(findings, LintFinding{
+			Path:    path,
+			Message: fmt.Sprintf("slider on %s requires minimum and maximum in the schema", control.Scope),
+		})
+	}
+
+	if variant, ok := control.Variant(); ok && typed && !slices.Contains(controlVariants[dataType], variant) {
+		findings = append(findings, LintFinding{
+			Path:    path,
+			Message: fmt.Sprintf("variant %q on %s is not supported for %s properties", variant, control.Scope, dataType),
+		})
+	}
+
+	return findings
+}
+
+// hasRange reports whether a schema defines numeric minimum and maximum bounds
+func hasRange(schema any) bool {
+	node, _ := schema.(map[string]any)
+	_, hasMinimum := NumberAsFloat64(node["minimum"])
+	_, hasMaximum := NumberAsFloat64(node["maximum"])
+
+	return hasMinimum && hasMaximum
+}
@@ -0,0 +1,234 @@
+package jsonforms
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintRules(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{
+				"type": "Control",
+				"scope": "#/properties/name",
+				"rule": {
+					"effect": "SHOW",
+					"condition": {
+						"type": "AND",
+						"conditions": []
+					}
+				}
+			},
+			{
+				"type": "Control",
+				"scope": "#/properties/email",
+				"rule": {
+					"effect": "COLLAPSE",
+					"condition": {
+						"type": "LEAF",
+						"scope": "#/properties/subscribe",
+						"expectedValue": true
+					}
+				}
+			},
+			{
+				"type": "Control",
+				"scope": "#/properties/phone",
+				"rule": {
+					"effect": "HIDE",
+					"condition": {
+						"scope": "#/properties/subscribe",
+						"schema": {"const": false}
+					}
+				}
+			},
+			{
+				"type": "Control",
+				"scope": "#/properties/fax",
+				"rule": {
+					"effect": "HIDE",
+					"condition": {
+						"type": "LEAF",
+						"scope": "#/properties/phone",
+						"expectedValue": null
+					}
+				}
+			}
+		]
+	}`)
+
+	result, err := Parse(uiSchema, nil)
+	require.NoError(t, err)
+
+	findings := LintRules(result.UISchema)
+
+	assert.Equal(t, []LintFinding{
+		{Path: "elements[0]", Message: "AND condition has no conditions"},
+		{Path: "elements[1]", Message: `invalid rule effect "COLLAPSE"`},
+	}, findings)
+}
+
+func TestLintOptionsToggle(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{"type": "Control", "scope": "#/properties/subscribe", "options": {"toggle": true}},
+			{"type": "Control", "scope": "#/properties/name", "options": {"toggle": true}},
+			{"type": "Control", "scope": "#/properties/name"}
+		]
+	}`)
+	schema := []byte(`{
+		"type": "object",
+		"properties": {
+			"subscribe": {"type": "boolean"},
+			"name": {"type": "string"}
+		}
+	}`)
+
+	result, err := Parse(uiSchema, schema)
+	require.NoError(t, err)
+
+	layout, ok := result.UISchema.(*VerticalLayout)
+	require.True(t, ok, "Expected VerticalLayout, got %T", result.UISchema)
+
+	toggle, ok := layout.Elements[0].(*Control)
+	require.True(t, ok, "Expected Control, got %T", layout.Elements[0])
+	assert.True(t, toggle.IsToggle())
+
+	plain, ok := layout.Elements[2].(*Control)
+	require.True(t, ok, "Expected Control, got %T", layout.Elements[2])
+	assert.False(t, plain.IsToggle())
+
+	findings := LintOptions(result)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "elements[1]", findings[0].Path)
+	assert.Contains(t, findings[0].Message, "boolean")
+
+	result.Schema = nil
+	assert.Empty(t, LintOptions(result))
+}
+
+func TestLintOptionsVariant(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{"type": "Control", "scope": "#/properties/quantity", "options": {"variant": "stepper"}},
+			{"type": "Control", "scope": "#/properties/name", "options": {"variant": "stepper"}}
+		]
+	}`), []byte(`{
+		"type": "object",
+		"properties": {
+			"quantity": {"type": "integer"},
+			"name": {"type": "string"}
+		}
+	}`))
+	require.NoError(t, err)
+
+	findings := LintOptions(result)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "elements[1]", findings[0].Path)
+	assert.Contains(t, findings[0].Message, `variant "stepper"`)
+}
+
+func TestLintOptionsSlider(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{"type": "Control", "scope": "#/properties/volume", "options": {"slider": true}},
+			{"type": "Control", "scope": "#/properties/rating", "options": {"slider": true}}
+		]
+	}`), []byte(`{
+		"type": "object",
+		"properties": {
+			"volume": {"type": "number", "minimum": 0, "maximum": 11},
+			"rating": {"type": "integer", "minimum": 1}
+		}
+	}`))
+	require.NoError(t, err)
+
+	layout, ok := result.UISchema.(*VerticalLayout)
+	require.True(t, ok, "Expected VerticalLayout, got %T", result.UISchema)
+
+	volume, ok := layout.Elements[0].(*Control)
+	require.True(t, ok, "Expected Control, got %T", layout.Elements[0])
+	assert.True(t, volume.IsSlider())
+	assert.False(t, NewControl("#/properties/volume").IsSlider())
+
+	findings := LintOptions(result)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "elements[1]", findings[0].Path)
+	assert.Contains(t, findings[0].Message, "minimum and maximum")
+}
+
+func TestLintOptionsUntypedProperty(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{"type": "Control", "scope": "#/properties/choice", "options": {"toggle": true}},
+			{"type": "Control", "scope": "#/properties/level", "options": {"variant": "stepper", "slider": true}}
+		]
+	}`), []byte(`{
+		"type": "object",
+		"properties": {
+			"choice": {"oneOf": [{"const": true}, {"const": false}]},
+			"level": {"$ref": "#/definitions/level"}
+		}
+	}`))
+	require.NoError(t, err)
+
+	findings := LintOptions(result)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "elements[1]", findings[0].Path)
+	assert.Contains(t, findings[0].Message, "minimum and maximum")
+}
+
+func TestLintDetailLayouts(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [{
+			"type": "Control",
+			"scope": "#/properties/contacts",
+			"options": {
+				"detail": {
+					"type": "VerticalLayout",
+					"elements": [
+						{"type": "Control", "scope": "#/properties/name", "options": {"toggle": true}},
+						{
+							"type": "Control",
+							"scope": "#/properties/primary",
+							"options": {"toggle": true},
+							"rule": {"effect": "SHOW", "condition": {"type": "OR", "conditions": []}}
+						}
+					]
+				}
+			}
+		}]
+	}`), []byte(`{
+		"type": "object",
+		"properties": {
+			"contacts": {
+				"type": "array",
+				"items": {
+					"type": "object",
+					"properties": {
+						"name": {"type": "string"},
+						"primary": {"type": "boolean"}
+					}
+				}
+			}
+		}
+	}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, []LintFinding{
+		{Path: "elements[0].detail.elements[1]", Message: "OR condition has no conditions"},
+	}, LintRules(result.UISchema))
+
+	findings := LintOptions(result)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "elements[0].detail.elements[0]", findings[0].Path)
+	assert.Contains(t, findings[0].Message, "requires a boolean property")
+}
@@ -0,0 +1,334 @@
+package jsonforms
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+)
+
+// Static errors for encoding
+var (
+	ErrNilElement = errors.New("element is nil")
+)
+
+// MarshalJSON always includes the condition type, using "SCHEMA_BASED" when it was omitted in the source
+func (s *SchemaBasedCondition) MarshalJSON() ([]byte, error) {
+	type plain SchemaBasedCondition
+
+	typed := plain(*s)
+	typed.Type = s.GetType()
+
+	return json.Marshal(typed)
+}
+
+// MarshalJSON writes a parsed detail layout over the raw options.detail
+func (c *Control) MarshalJSON() ([]byte, error) {
+	type plain Control
+
+	typed := plain(*c)
+	typed.Options = optionsWithDetail(c.Options, c.Detail)
+
+	return marshalWithRules(typed, &c.BaseUISchemaElement)
+}
+
+// MarshalJSON writes a parsed detail layout over the raw options.detail
+func (l *ListWithDetail) MarshalJSON() ([]byte, error) {
+	type plain ListWithDetail
+
+	typed := plain(*l)
+	typed.Options = optionsWithDetail(l.Options, l.Detail)
+
+	return marshalWithRules(typed, &l.BaseUISchemaElement)
+}
+
+// MarshalJSON includes every rule, as for all standard elements
+func (v *VerticalLayout) MarshalJSON() ([]byte, error) {
+	type plain VerticalLayout
+
+	return marshalWithRules((*plain)(v), &v.BaseUISchemaElement)
+}
+
+// MarshalJSON includes every rule, as for all standard elements
+func (h *HorizontalLayout) MarshalJSON() ([]byte, error) {
+	type plain HorizontalLayout
+
+	return marshalWithRules((*plain)(h), &h.BaseUISchemaElement)
+}
+
+// MarshalJSON includes every rule, as for all standard elements
+func (g *Group) MarshalJSON() ([]byte, error) {
+	type plain Group
+
+	return marshalWithRules((*plain)(g), &g.BaseUISchemaElement)
+}
+
+// MarshalJSON includes every rule, as for all standard elements
+func (c *Categorization) MarshalJSON() ([]byte, error) {
+	type plain Categorization
+
+	return marshalWithRules((*plain)(c), &c.BaseUISchemaElement)
+}
+
+// MarshalJSON includes every rule, as for all standard elements
+func (c *Category) MarshalJSON() ([]byte, error) {
+	type plain Category
+
+	return marshalWithRules((*plain)(c), &c.BaseUISchemaElement)
+}
+
+// MarshalJSON includes every rule, as for all standard elements
+func (l *Label) MarshalJSON() ([]byte, error) {
+	type plain Label
+
+	return marshalWithRules((*plain)(l), &l.BaseUISchemaElement)
+}
+
+// marshalWithRules encodes a typed element whose Rules is set with its rules written as
+// MarshalElement does: a single rule under "rule", several as a "rules" array. Rules beyond
+// the first would otherwise be dropped, as the Rules field is not encoded.
+func marshalWithRules(typed any, base *BaseUISchemaElement) ([]byte, error) {
+	data, err := json.Marshal(typed)
+	if err != nil || len(base.Rules) == 0 {
+		return data, err
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	delete(fields, "rule")
+	delete(fields, "rules")
+
+	switch rules := base.GetRules(); len(rules) {
+	case 0:
+	case 1:
+		fields["rule"], err = json.Marshal(rules[0])
+	default:
+		fields["rules"], err = json.Marshal(rules)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(fields)
+}
+
+// optionsWithDetail returns a copy of options with detail set, or options itself when there is no detail
+func optionsWithDetail(options map[string]any, detail any) map[string]any {
+	if detail == nil {
+		return options
+	}
+
+	result := make(map[string]any, len(options)+1)
+	for key, option := range options {
+		result[key] = option
+	}
+
+	result["detail"] = detail
+
+	return result
+}
+
+// MarshalJSON emits the raw condition data unchanged
+func (c *CustomCondition) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.RawData)
+}
+
+// MarshalJSON emits the raw element data overlaid with the parsed type, rule, options, i18n and children
+func (c *CustomElement) MarshalJSON() ([]byte, error) {
+	type plain CustomElement
+
+	typed, err := marshalWithRules((*plain)(c), &c.BaseUISchemaElement)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]any{}
+	if err := json.Unmarshal(typed, &fields); err != nil {
+		return nil, err
+	}
+
+	merged := make(map[string]any, len(c.RawData)+len(fields))
+	for key, value := range c.RawData {
+		merged[key] = value
+	}
+
+	// The raw data may still hold the rules as written
+	delete(merged, "rule")
+	delete(merged, "rules")
+
+	for key, value := range fields {
+		merged[key] = value
+	}
+
+	return json.Marshal(merged)
+}
+
+// MarshalJSON emits the parse error together with the raw element data
+func (i *InvalidElement) MarshalJSON() ([]byte, error) {
+	invalid := map[string]any{"rawData": i.RawData}
+	if i.Err != nil {
+		invalid["error"] = i.Err.Error()
+	}
+
+	return json.Marshal(invalid)
+}
+
+// MarshalElement encodes a single element and its children as JSON Forms UI schema JSON, for
+// example to copy part of a form. Unrecognized keys and every rule are re-emitted, so the output
+// parses back into an equivalent element.
+func MarshalElement(el UISchemaElement) ([]byte, error) {
+	if el == nil {
+		return nil, ErrNilElement
+	}
+
+	return json.Marshal(elementJSON(el))
+}
+
+// elementJSON builds the JSON object for an element and, recursively, its children
+func elementJSON(el UISchemaElement) map[string]any {
+	switch e := el.(type) {
+	case *CustomElement:
+		object := make(map[string]any, len(e.RawData))
+		for key, value := range e.RawData {
+			object[key] = value
+		}
+
+		addBaseJSON(object, &e.BaseUISchemaElement)
+
+		if len(e.Elements) > 0 {
+			object["elements"] = elementsJSON(e.Elements)
+		}
+
+		return object
+	case *InvalidElement:
+		return e.RawData
+	}
+
+	object := map[string]any{}
+	if b, ok := el.(interface{ base() *BaseUISchemaElement }); ok {
+		for key, value := range b.base().Extra {
+			object[key] = value
+		}
+
+		addBaseJSON(object, b.base())
+	}
+
+	switch e := el.(type) {
+	case *Control:
+		object["scope"] = e.Scope
+		if e.Label != nil {
+			object["label"] = e.Label
+		}
+
+		addDetailJSON(object, e.Detail)
+	case *ListWithDetail:
+		object["scope"] = e.Scope
+
+		addDetailJSON(object, e.Detail)
+	case *VerticalLayout:
+		object["elements"] = elementsJSON(e.Elements)
+	case *HorizontalLayout:
+		object["elements"] = elementsJSON(e.Elements)
+	case *Group:
+		object["label"] = e.Label
+		object["elements"] = elementsJSON(e.Elements)
+	case *Categorization:
+		if e.Label != nil {
+			object["label"] = *e.Label
+		}
+
+		elements := make([]any, 0, len(e.Elements))
+		for _, child := range e.Elements {
+			elements = append(elements, elementJSON(child))
+		}
+
+		object["elements"] = elements
+	case *Category:
+		object["label"] = e.Label
+		object["elements"] = elementsJSON(e.Elements)
+	case *Label:
+		object["text"] = e.Text
+
+		// A show hint read from options.show is re-emitted there
+		if _, inOptions := e.Options["show"]; e.Show != nil && !inOptions {
+			object["show"] = *e.Show
+		}
+	}
+
+	return object
+}
+
+// elementsJSON builds the JSON array for a list of child elements
+func elementsJSON(elements []UISchemaElement) []any {
+	result := make([]any, 0, len(elements))
+	for _, child := range elements {
+		result = append(result, elementJSON(child))
+	}
+
+	return result
+}
+
+// addDetailJSON writes a parsed detail layout back into options.detail
+func addDetailJSON(object map[string]any, detail UISchemaElement) {
+	if detail == nil {
+		return
+	}
+
+	options, _ := object["options"].(map[string]any)
+	object["options"] = optionsWithDetail(options, elementJSON(detail))
+}
+
+// addBaseJSON sets the type, options, i18n key and rules shared by all elements
+func addBaseJSON(object map[string]any, base *BaseUISchemaElement) {
+	object["type"] = base.Type
+
+	if base.Options != nil {
+		object["options"] = base.Options
+	}
+
+	if base.I18n != nil {
+		object["i18n"] = *base.I18n
+	}
+
+	// Raw custom element data may still hold the rules as written
+	delete(object, "rule")
+	delete(object, "rules")
+
+	switch rules := base.GetRules(); len(rules) {
+	case 0:
+	case 1:
+		object["rule"] = rules[0]
+	default:
+		object["rules"] = rules
+	}
+}
+
+// Fingerprint returns a SHA-256 hex digest of the tree's content as encoded by MarshalElement:
+// element types, scopes, labels, options, every rule and detail layouts. Whitespace, key order and
+// number formatting in the source do not affect it, so "1.0" and 1 hash alike with or without
+// ParseOptions.UseNumber. It returns "" when root is nil or holds a value that cannot be encoded,
+// such as a channel in its options.
+func Fingerprint(root UISchemaElement) string {
+	data, err := MarshalElement(root)
+	if err != nil {
+		return ""
+	}
+
+	// Decoding numbers as float64 and re-encoding with sorted map keys makes the JSON canonical
+	var canonical any
+	if err := json.Unmarshal(data, &canonical); err != nil {
+		return ""
+	}
+
+	if data, err = json.Marshal(canonical); err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
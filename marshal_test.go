@@ -0,0 +1,286 @@
+package jsonforms
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalTypedAST(t *testing.T) {
+	result, err := ParseWithOptions([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{
+				"type": "Control",
+				"scope": "#/properties/email",
+				"rule": {
+					"effect": "SHOW",
+					"condition": {"scope": "#/properties/subscribe", "schema": {"const": true}}
+				}
+			},
+			{
+				"type": "Rating",
+				"scope": "#/properties/rating",
+				"stars": 5,
+				"rule": {
+					"effect": "HIDE",
+					"condition": {"type": "ALWAYS", "reason": "demo"}
+				}
+			}
+		]
+	}`), nil, ParseOptions{AllowCustomConditions: true})
+	require.NoError(t, err)
+
+	data, err := json.Marshal(result.UISchema)
+	require.NoError(t, err)
+
+	expected := `{
+		"type": "VerticalLayout",
+		"elements": [
+			{
+				"type": "Control",
+				"scope": "#/properties/email",
+				"rule": {
+					"effect": "SHOW",
+					"condition": {"type": "SCHEMA_BASED", "scope": "#/properties/subscribe", "schema": {"const": true}}
+				}
+			},
+			{
+				"type": "Rating",
+				"scope": "#/properties/rating",
+				"stars": 5,
+				"rule": {
+					"effect": "HIDE",
+					"condition": {"type": "ALWAYS", "reason": "demo"}
+				}
+			}
+		]
+	}`
+	assert.JSONEq(t, expected, string(data))
+}
+
+func TestMarshalTypedASTRules(t *testing.T) {
+	uiSchema := `{
+		"type": "VerticalLayout",
+		"elements": [{
+			"type": "Group",
+			"label": "Billing",
+			"rules": [
+				{"effect": "HIDE", "condition": {"type": "LEAF", "scope": "#/properties/free", "expectedValue": true}},
+				{"effect": "DISABLE", "condition": {"type": "LEAF", "scope": "#/properties/locked", "expectedValue": true}}
+			],
+			"elements": [{"type": "Control", "scope": "#/properties/card"}]
+		}]
+	}`
+
+	result, err := Parse([]byte(uiSchema), nil)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(result.UISchema)
+	require.NoError(t, err)
+	assert.JSONEq(t, uiSchema, string(data))
+
+	reparsed, err := Parse(data, nil)
+	require.NoError(t, err)
+
+	layout, ok := reparsed.UISchema.(*VerticalLayout)
+	require.True(t, ok, "Expected VerticalLayout, got %T", reparsed.UISchema)
+
+	rules := ElementRules(layout.Elements[0])
+	require.Len(t, rules, 2)
+	assert.Equal(t, RuleEffectDISABLE, rules[1].Effect)
+}
+
+func TestMarshalInvalidElement(t *testing.T) {
+	result, err := ParseWithOptions([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [{"type": "Label"}]
+	}`), nil, ParseOptions{SkipInvalidElements: true})
+	require.NoError(t, err)
+
+	data, err := json.Marshal(result.UISchema)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "VerticalLayout",
+		"elements": [
+			{"error": "element 0: Label missing required 'text' field", "rawData": {"type": "Label"}}
+		]
+	}`, string(data))
+}
+
+func TestFingerprint(t *testing.T) {
+	compact, err := Parse([]byte(`{"type":"VerticalLayout","elements":[{"type":"Control","scope":"#/properties/name","options":{"trim":true,"focus":false}},{"type":"Control","scope":"#/properties/email","rule":{"effect":"SHOW","condition":{"type":"LEAF","scope":"#/properties/subscribe","expectedValue":true}}}]}`), nil)
+	require.NoError(t, err)
+
+	formatted, err := Parse([]byte(`{
+		"elements": [
+			{
+				"options": {"focus": false, "trim": true},
+				"scope": "#/properties/name",
+				"type": "Control"
+			},
+			{
+				"rule": {
+					"condition": {"expectedValue": true, "scope": "#/properties/subscribe", "type": "LEAF"},
+					"effect": "SHOW"
+				},
+				"type": "Control",
+				"scope": "#/properties/email"
+			}
+		],
+		"type": "VerticalLayout"
+	}`), nil)
+	require.NoError(t, err)
+
+	fingerprint := Fingerprint(compact.UISchema)
+	assert.Len(t, fingerprint, 64)
+	assert.Equal(t, fingerprint, Fingerprint(formatted.UISchema))
+
+	RenameScope(formatted.UISchema, "#/properties/name", "#/properties/fullName")
+	assert.NotEqual(t, fingerprint, Fingerprint(formatted.UISchema))
+}
+
+func TestFingerprintNumbersAndDetail(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "Control",
+		"scope": "#/properties/items",
+		"options": {
+			"max": 1.0,
+			"detail": {"type": "VerticalLayout", "elements": [{"type": "Control", "scope": "#/properties/qty"}]}
+		}
+	}`)
+
+	floats, err := Parse(uiSchema, nil)
+	require.NoError(t, err)
+
+	numbers, err := ParseWithOptions(uiSchema, nil, ParseOptions{UseNumber: true})
+	require.NoError(t, err)
+
+	integer, err := Parse([]byte(strings.Replace(string(uiSchema), "1.0", "1", 1)), nil)
+	require.NoError(t, err)
+
+	fingerprint := Fingerprint(floats.UISchema)
+	assert.Equal(t, fingerprint, Fingerprint(numbers.UISchema))
+	assert.Equal(t, fingerprint, Fingerprint(integer.UISchema))
+
+	control, ok := floats.UISchema.(*Control)
+	require.True(t, ok, "Expected Control, got %T", floats.UISchema)
+
+	detail, ok := control.Detail.(*VerticalLayout)
+	require.True(t, ok, "Expected VerticalLayout detail, got %T", control.Detail)
+	detail.Elements = nil
+	assert.NotEqual(t, fingerprint, Fingerprint(control))
+
+	control.Options["bad"] = make(chan int)
+	assert.Empty(t, Fingerprint(control))
+	assert.Empty(t, Fingerprint(nil))
+}
+
+func TestMarshalElement(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{
+				"type": "Group",
+				"label": "Address",
+				"i18n": "address",
+				"x-note": "kept",
+				"elements": [
+					{"type": "Control", "scope": "#/properties/street", "label": "Street"},
+					{"type": "Label", "text": "Optional", "show": false}
+				],
+				"rules": [
+					{"effect": "HIDE", "condition": {"type": "LEAF", "scope": "#/properties/hidden", "expectedValue": true}},
+					{"effect": "DISABLE", "condition": {"scope": "#/properties/locked", "schema": {"const": true}}}
+				]
+			},
+			{
+				"type": "Control",
+				"scope": "#/properties/notes",
+				"options": {"multi": true, "trim": true}
+			}
+		]
+	}`), nil)
+	require.NoError(t, err)
+
+	layout, ok := result.UISchema.(*VerticalLayout)
+	require.True(t, ok, "Expected VerticalLayout, got %T", result.UISchema)
+
+	group, err := MarshalElement(layout.Elements[0])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "Group",
+		"label": "Address",
+		"i18n": "address",
+		"x-note": "kept",
+		"elements": [
+			{"type": "Control", "scope": "#/properties/street", "label": "Street"},
+			{"type": "Label", "text": "Optional", "show": false}
+		],
+		"rules": [
+			{"effect": "HIDE", "condition": {"type": "LEAF", "scope": "#/properties/hidden", "expectedValue": true}},
+			{"effect": "DISABLE", "condition": {"type": "SCHEMA_BASED", "scope": "#/properties/locked", "schema": {"const": true}}}
+		]
+	}`, string(group))
+
+	control, err := MarshalElement(layout.Elements[1])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "Control",
+		"scope": "#/properties/notes",
+		"options": {"multi": true, "trim": true}
+	}`, string(control))
+
+	reparsed, err := Parse(group, nil)
+	require.NoError(t, err)
+	assert.Equal(t, Fingerprint(layout.Elements[0]), Fingerprint(reparsed.UISchema))
+}
+
+func TestMarshalElementDetailRoundTrip(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "Control",
+		"scope": "#/properties/contacts",
+		"options": {
+			"showSortButtons": true,
+			"detail": {
+				"type": "VerticalLayout",
+				"elements": [{"type": "Control", "scope": "#/properties/name"}]
+			}
+		}
+	}`), nil)
+	require.NoError(t, err)
+
+	control, ok := result.UISchema.(*Control)
+	require.True(t, ok, "Expected Control, got %T", result.UISchema)
+
+	detail, ok := control.Detail.(*VerticalLayout)
+	require.True(t, ok, "Expected VerticalLayout detail, got %T", control.Detail)
+	detail.Elements = append(detail.Elements, NewControl("#/properties/phone"))
+
+	data, err := MarshalElement(control)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "Control",
+		"scope": "#/properties/contacts",
+		"options": {
+			"showSortButtons": true,
+			"detail": {
+				"type": "VerticalLayout",
+				"elements": [
+					{"type": "Control", "scope": "#/properties/name"},
+					{"type": "Control", "scope": "#/properties/phone"}
+				]
+			}
+		}
+	}`, string(data))
+
+	reparsed, err := Parse(data, nil)
+	require.NoError(t, err)
+	assert.Len(t, LeafControls(reparsed.UISchema), 3)
+
+	_, err = MarshalElement(nil)
+	require.ErrorIs(t, err, ErrNilElement)
+}
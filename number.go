@@ -0,0 +1,55 @@
+package jsonforms
+
+import (
+	"encoding/json"
+	"math"
+)
+
+// NumberAsFloat64 reads a decoded JSON number as float64. It accepts float64 values produced
+// by the default decoder, json.Number values produced under ParseOptions.UseNumber, and Go integers.
+func NumberAsFloat64(value any) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := n.Float64()
+
+		return f, err == nil
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
+
+// NumberAsInt64 reads a decoded JSON number as int64. Values with a fractional part
+// or outside the int64 range are rejected rather than truncated.
+func NumberAsInt64(value any) (int64, bool) {
+	switch n := value.(type) {
+	case float64:
+		if n != math.Trunc(n) || n >= math.MaxInt64 || n < math.MinInt64 {
+			return 0, false
+		}
+
+		return int64(n), true
+	case json.Number:
+		if i, err := n.Int64(); err == nil {
+			return i, true
+		}
+
+		f, err := n.Float64()
+		if err != nil {
+			return 0, false
+		}
+
+		return NumberAsInt64(f)
+	case int:
+		return int64(n), true
+	case int64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
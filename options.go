@@ -0,0 +1,235 @@
+package jsonforms
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"strings"
+)
+
+// Static errors for option access
+var (
+	ErrInvalidOptionPath = errors.New("invalid option path")
+)
+
+// stringOption returns an option value when it is present and a string
+func (b *BaseUISchemaElement) stringOption(key string) (string, bool) {
+	value, ok := b.Options[key].(string)
+
+	return value, ok
+}
+
+// ElementLabelProp returns the item property used to label each row of an array control
+func (c *Control) ElementLabelProp() (string, bool) {
+	return c.stringOption("elementLabelProp")
+}
+
+// Description returns the control's options.description help text
+func (c *Control) Description() (string, bool) {
+	return c.stringOption("description")
+}
+
+// Placeholder returns the control's options.placeholder input hint
+func (c *Control) Placeholder() (string, bool) {
+	return c.stringOption("placeholder")
+}
+
+// boolOption returns an option value when it is present and a boolean
+func (b *BaseUISchemaElement) boolOption(key string) (bool, bool) {
+	value, ok := b.Options[key].(bool)
+
+	return value, ok
+}
+
+// Variant returns the categorization's options.variant, e.g. "stepper"
+func (c *Categorization) Variant() (string, bool) {
+	return c.stringOption("variant")
+}
+
+// Variant returns the control's options.variant rendering hint, e.g. "stepper" for a number input
+func (c *Control) Variant() (string, bool) {
+	return c.stringOption("variant")
+}
+
+// ShowNavButtons returns the stepper's options.showNavButtons flag
+func (c *Categorization) ShowNavButtons() (bool, bool) {
+	return c.boolOption("showNavButtons")
+}
+
+// GetIntOption returns an option as an int when it holds a whole number.
+// JSON numbers decode as float64, so fractional values are rejected rather than truncated.
+func (b *BaseUISchemaElement) GetIntOption(key string) (int, bool) {
+	value, ok := NumberAsInt64(b.Options[key])
+	if !ok || value > math.MaxInt || value < math.MinInt {
+		return 0, false
+	}
+
+	return int(value), true
+}
+
+// IsReadonly reports whether the control sets options.readonly to true
+func (c *Control) IsReadonly() bool {
+	readonly, _ := c.boolOption("readonly")
+
+	return readonly
+}
+
+// DetailMode returns options.detail when it is a string such as "DEFAULT" or "GENERATED".
+// An object detail is parsed into Control.Detail instead and reports false here.
+func (c *Control) DetailMode() (string, bool) {
+	return c.stringOption("detail")
+}
+
+// IsMultiline reports whether the control should render as a textarea, either through
+// options.multi or options.format set to "textarea"
+func (c *Control) IsMultiline() bool {
+	if multi, _ := c.boolOption("multi"); multi {
+		return true
+	}
+
+	format, _ := c.stringOption("format")
+
+	return format == "textarea"
+}
+
+// IsToggle reports whether the control sets options.toggle to render a boolean as a switch
+func (c *Control) IsToggle() bool {
+	toggle, _ := c.boolOption("toggle")
+
+	return toggle
+}
+
+// ShouldTrim reports whether the control sets options.trim, so input is stripped of surrounding whitespace
+func (c *Control) ShouldTrim() bool {
+	trim, _ := c.boolOption("trim")
+
+	return trim
+}
+
+// ShouldRestrict reports whether the control sets options.restrict, so input cannot exceed maxLength
+func (c *Control) ShouldRestrict() bool {
+	restrict, _ := c.boolOption("restrict")
+
+	return restrict
+}
+
+// LabelHidden reports whether the control suppresses its label with "label": false
+func (c *Control) LabelHidden() bool {
+	show, ok := c.Label.(bool)
+
+	return ok && !show
+}
+
+// LabelText returns the control's label text, whether given as a string or as a label
+// description object with a "text" field
+func (c *Control) LabelText() (string, bool) {
+	switch label := c.Label.(type) {
+	case string:
+		return label, true
+	case LabelDescription:
+		return label.Text, true
+	case *LabelDescription:
+		if label != nil {
+			return label.Text, true
+		}
+	case map[string]any:
+		text, ok := label["text"].(string)
+
+		return text, ok
+	}
+
+	return "", false
+}
+
+// GetOptionPath reads a nested option by dot-separated keys, e.g. "detail.type" reads
+// options.detail.type
+func GetOptionPath(el UISchemaElement, path string) (any, bool) {
+	var current any = el.GetOptions()
+
+	for _, key := range strings.Split(path, ".") {
+		node, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+
+		if current, ok = node[key]; !ok {
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+// SetOptionPath writes a nested option by dot-separated keys, creating the options map and any
+// missing intermediate objects. It fails when an intermediate key holds a non-object value. Maps
+// along the path are copied rather than changed in place. On an element with a parsed Detail, a
+// write under "detail" re-parses Detail from the updated options, replacing direct edits to it,
+// and fails without changing the element when the result is not a valid layout.
+func SetOptionPath(el UISchemaElement, path string, value any) error {
+	b, ok := el.(interface{ base() *BaseUISchemaElement })
+	if !ok || path == "" {
+		return fmt.Errorf("%w: %q", ErrInvalidOptionPath, path)
+	}
+
+	keys := strings.Split(path, ".")
+
+	options := maps.Clone(b.base().Options)
+	if options == nil {
+		options = map[string]any{}
+	}
+
+	node := options
+
+	for _, key := range keys[:len(keys)-1] {
+		next, exists := node[key]
+		if !exists {
+			child := map[string]any{}
+			node[key] = child
+			node = child
+
+			continue
+		}
+
+		child, ok := next.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%w: %q is not an object", ErrInvalidOptionPath, key)
+		}
+
+		child = maps.Clone(child)
+		node[key] = child
+		node = child
+	}
+
+	node[keys[len(keys)-1]] = value
+
+	if keys[0] == "detail" && detailOf(el) != nil {
+		detail, err := (&parser{}).parseDetail(options)
+		if err != nil {
+			return err
+		}
+
+		setDetail(el, detail)
+	}
+
+	b.base().Options = options
+
+	return nil
+}
+
+// setDetail replaces the parsed detail layout of a control or list
+func setDetail(el UISchemaElement, detail UISchemaElement) {
+	switch e := el.(type) {
+	case *Control:
+		e.Detail = detail
+	case *ListWithDetail:
+		e.Detail = detail
+	}
+}
+
+// IsSlider reports whether the control sets options.slider to render a number as a range slider
+func (c *Control) IsSlider() bool {
+	slider, _ := c.boolOption("slider")
+
+	return slider
+}
@@ -0,0 +1,333 @@
+package jsonforms
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCategorizationStepperOptions(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "Categorization",
+		"options": {
+			"variant": "stepper",
+			"showNavButtons": true
+		},
+		"elements": [
+			{
+				"type": "Category",
+				"label": "First",
+				"elements": []
+			}
+		]
+	}`)
+
+	result, err := Parse(uiSchema, nil)
+	require.NoError(t, err)
+
+	categorization, ok := result.UISchema.(*Categorization)
+	require.True(t, ok, "Expected Categorization, got %T", result.UISchema)
+
+	variant, ok := categorization.Variant()
+	require.True(t, ok)
+	assert.Equal(t, "stepper", variant)
+
+	showNavButtons, ok := categorization.ShowNavButtons()
+	require.True(t, ok)
+	assert.True(t, showNavButtons)
+}
+
+func TestCategorizationDefaultOptions(t *testing.T) {
+	categorization := &Categorization{}
+
+	_, ok := categorization.Variant()
+	assert.False(t, ok)
+
+	_, ok = categorization.ShowNavButtons()
+	assert.False(t, ok)
+}
+
+func TestOptionValueFidelity(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "Control",
+		"scope": "#/properties/age",
+		"options": {
+			"placeholder": null,
+			"step": 0.5,
+			"maxRows": 4
+		}
+	}`)
+
+	result, err := Parse(uiSchema, nil)
+	require.NoError(t, err)
+
+	control, ok := result.UISchema.(*Control)
+	require.True(t, ok, "Expected Control, got %T", result.UISchema)
+
+	value, present := control.Options["placeholder"]
+	assert.True(t, present, "Expected null option to be kept")
+	assert.Nil(t, value)
+
+	_, ok = control.GetIntOption("step")
+	assert.False(t, ok)
+	assert.InDelta(t, 0.5, control.Options["step"], 0)
+
+	maxRows, ok := control.GetIntOption("maxRows")
+	require.True(t, ok)
+	assert.Equal(t, 4, maxRows)
+
+	_, ok = control.GetIntOption("placeholder")
+	assert.False(t, ok)
+}
+
+func TestControlDescriptionAndPlaceholder(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "Control",
+		"scope": "#/properties/email",
+		"options": {
+			"description": "We never share your email",
+			"placeholder": "you@example.com"
+		}
+	}`)
+
+	result, err := Parse(uiSchema, nil)
+	require.NoError(t, err)
+
+	control, ok := result.UISchema.(*Control)
+	require.True(t, ok, "Expected Control, got %T", result.UISchema)
+
+	description, ok := control.Description()
+	require.True(t, ok)
+	assert.Equal(t, "We never share your email", description)
+
+	placeholder, ok := control.Placeholder()
+	require.True(t, ok)
+	assert.Equal(t, "you@example.com", placeholder)
+
+	absent := &Control{Scope: "#/properties/email"}
+
+	_, ok = absent.Description()
+	assert.False(t, ok)
+
+	_, ok = absent.Placeholder()
+	assert.False(t, ok)
+
+	nonString := &Control{BaseUISchemaElement: BaseUISchemaElement{Options: map[string]any{"placeholder": 42.0}}}
+
+	_, ok = nonString.Placeholder()
+	assert.False(t, ok)
+}
+
+func TestControlDetailMode(t *testing.T) {
+	generated, err := Parse([]byte(`{
+		"type": "Control",
+		"scope": "#/properties/contacts",
+		"options": {"detail": "GENERATED"}
+	}`), nil)
+	require.NoError(t, err)
+
+	control, ok := generated.UISchema.(*Control)
+	require.True(t, ok, "Expected Control, got %T", generated.UISchema)
+
+	mode, ok := control.DetailMode()
+	require.True(t, ok)
+	assert.Equal(t, "GENERATED", mode)
+	assert.Nil(t, control.Detail)
+
+	object, err := Parse([]byte(`{
+		"type": "Control",
+		"scope": "#/properties/contacts",
+		"options": {
+			"detail": {
+				"type": "VerticalLayout",
+				"elements": [{"type": "Control", "scope": "#/properties/name"}]
+			}
+		}
+	}`), nil)
+	require.NoError(t, err)
+
+	control, ok = object.UISchema.(*Control)
+	require.True(t, ok, "Expected Control, got %T", object.UISchema)
+
+	_, ok = control.DetailMode()
+	assert.False(t, ok)
+	assert.NotNil(t, control.Detail)
+
+	_, ok = (&Control{Scope: "#/properties/contacts"}).DetailMode()
+	assert.False(t, ok)
+}
+
+func TestControlIsMultiline(t *testing.T) {
+	tests := []struct {
+		name    string
+		options map[string]any
+		want    bool
+	}{
+		{name: "multi", options: map[string]any{"multi": true}, want: true},
+		{name: "textarea format", options: map[string]any{"format": "textarea"}, want: true},
+		{name: "multi disabled", options: map[string]any{"multi": false}, want: false},
+		{name: "plain", options: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			control := NewControl("#/properties/notes").WithOptions(tt.options)
+			assert.Equal(t, tt.want, control.IsMultiline())
+		})
+	}
+}
+
+func TestControlTrimAndRestrict(t *testing.T) {
+	control := NewControl("#/properties/code").WithOptions(map[string]any{"trim": true, "restrict": true})
+	assert.True(t, control.ShouldTrim())
+	assert.True(t, control.ShouldRestrict())
+
+	plain := NewControl("#/properties/code").WithOptions(map[string]any{"trim": "yes"})
+	assert.False(t, plain.ShouldTrim())
+	assert.False(t, plain.ShouldRestrict())
+}
+
+func TestControlVariant(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{"type": "Control", "scope": "#/properties/quantity", "options": {"variant": "stepper"}},
+			{"type": "Control", "scope": "#/properties/name"}
+		]
+	}`), nil)
+	require.NoError(t, err)
+
+	layout, ok := result.UISchema.(*VerticalLayout)
+	require.True(t, ok, "Expected VerticalLayout, got %T", result.UISchema)
+
+	quantity, ok := layout.Elements[0].(*Control)
+	require.True(t, ok, "Expected Control, got %T", layout.Elements[0])
+
+	variant, ok := quantity.Variant()
+	require.True(t, ok)
+	assert.Equal(t, "stepper", variant)
+
+	name, ok := layout.Elements[1].(*Control)
+	require.True(t, ok, "Expected Control, got %T", layout.Elements[1])
+
+	_, ok = name.Variant()
+	assert.False(t, ok)
+}
+
+func TestControlLabelHiddenAndText(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{"type": "Control", "scope": "#/properties/a", "label": false},
+			{"type": "Control", "scope": "#/properties/b", "label": "Name"},
+			{"type": "Control", "scope": "#/properties/c", "label": {"text": "Email", "show": true}},
+			{"type": "Control", "scope": "#/properties/d"}
+		]
+	}`), nil)
+	require.NoError(t, err)
+
+	layout, ok := result.UISchema.(*VerticalLayout)
+	require.True(t, ok, "Expected VerticalLayout, got %T", result.UISchema)
+
+	controls := make([]*Control, 0, len(layout.Elements))
+
+	for _, element := range layout.Elements {
+		control, ok := element.(*Control)
+		require.True(t, ok, "Expected Control, got %T", element)
+
+		controls = append(controls, control)
+	}
+
+	assert.True(t, controls[0].LabelHidden())
+	_, ok = controls[0].LabelText()
+	assert.False(t, ok)
+
+	assert.False(t, controls[1].LabelHidden())
+	text, ok := controls[1].LabelText()
+	require.True(t, ok)
+	assert.Equal(t, "Name", text)
+
+	assert.False(t, controls[2].LabelHidden())
+	text, ok = controls[2].LabelText()
+	require.True(t, ok)
+	assert.Equal(t, "Email", text)
+
+	assert.False(t, controls[3].LabelHidden())
+	_, ok = controls[3].LabelText()
+	assert.False(t, ok)
+
+	text, ok = (&Control{Label: &LabelDescription{Text: "Phone"}}).LabelText()
+	require.True(t, ok)
+	assert.Equal(t, "Phone", text)
+}
+
+func TestOptionPath(t *testing.T) {
+	control := NewControl("#/properties/contacts").WithOptions(map[string]any{
+		"detail": map[string]any{"type": "VerticalLayout"},
+		"trim":   true,
+	})
+
+	value, ok := GetOptionPath(control, "detail.type")
+	require.True(t, ok)
+	assert.Equal(t, "VerticalLayout", value)
+
+	_, ok = GetOptionPath(control, "detail.missing")
+	assert.False(t, ok)
+
+	_, ok = GetOptionPath(control, "trim.deeper")
+	assert.False(t, ok)
+
+	require.NoError(t, SetOptionPath(control, "detail.label", "Contact"))
+	require.NoError(t, SetOptionPath(control, "layout.columns.count", 2))
+	assert.Equal(t, map[string]any{"type": "VerticalLayout", "label": "Contact"}, control.Options["detail"])
+
+	value, ok = GetOptionPath(control, "layout.columns.count")
+	require.True(t, ok)
+	assert.Equal(t, 2, value)
+
+	require.ErrorIs(t, SetOptionPath(control, "trim.deeper", 1), ErrInvalidOptionPath)
+
+	label := NewLabel("Note")
+	require.NoError(t, SetOptionPath(label, "show", false))
+	assert.Equal(t, map[string]any{"show": false}, label.Options)
+}
+
+func TestOptionPathParsedDetail(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "Control",
+		"scope": "#/properties/contacts",
+		"options": {
+			"detail": {
+				"type": "VerticalLayout",
+				"elements": [{"type": "Control", "scope": "#/properties/email"}]
+			}
+		}
+	}`), nil)
+	require.NoError(t, err)
+
+	control, ok := result.UISchema.(*Control)
+	require.True(t, ok, "Expected Control, got %T", result.UISchema)
+
+	value, ok := GetOptionPath(control, "detail.type")
+	require.True(t, ok)
+	assert.Equal(t, "VerticalLayout", value)
+
+	require.NoError(t, SetOptionPath(control, "detail.type", "HorizontalLayout"))
+	assert.IsType(t, &HorizontalLayout{}, control.Detail)
+
+	value, ok = GetOptionPath(control, "detail.type")
+	require.True(t, ok)
+	assert.Equal(t, "HorizontalLayout", value)
+
+	data, err := MarshalElement(control)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"type":"HorizontalLayout"`)
+
+	require.ErrorIs(t, SetOptionPath(control, "detail.type", "Group"), ErrGroupMissingLabel)
+	assert.IsType(t, &HorizontalLayout{}, control.Detail)
+
+	value, ok = GetOptionPath(control, "detail.type")
+	require.True(t, ok)
+	assert.Equal(t, "HorizontalLayout", value)
+}
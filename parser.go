@@ -1,15 +1,22 @@
 package jsonforms
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
 )
 
 // Static errors for err113 compliance
 var (
 	ErrMissingTypeField              = errors.New("missing or invalid 'type' field")
 	ErrControlMissingScope           = errors.New("Control missing required 'scope' field")
+	ErrListWithDetailMissingScope    = errors.New("ListWithDetail missing required 'scope' field")
 	ErrGroupMissingLabel             = errors.New("Group missing required 'label' field")
 	ErrCategorizationMissingElements = errors.New("Categorization missing required 'elements' field")
 	ErrElementNotObject              = errors.New("element is not an object")
@@ -25,84 +32,305 @@ var (
 	ErrLeafConditionMissingValue     = errors.New("LeafCondition missing required 'expectedValue' field")
 	ErrAndConditionMissingConditions = errors.New("AndCondition missing required 'conditions' field")
 	ErrOrConditionMissingConditions  = errors.New("OrCondition missing required 'conditions' field")
+	ErrDuplicateKey                  = errors.New("duplicate JSON key")
+	ErrTrailingData                  = errors.New("unexpected data after top-level JSON value")
+	ErrUnexpectedToken               = errors.New("unexpected JSON token")
+	ErrInvalidRules                  = errors.New("'rules' must be an array of rule objects")
+	ErrUnknownConditionField         = errors.New("unknown condition field")
+	ErrInvalidShowOn                 = errors.New("'options.showOn' must be an object with a string 'scope' and a 'value'")
+	ErrInvalidUISchemas              = errors.New("'uischemas' must be an array of objects with a 'uischema' object")
 )
 
+// ParseOptions configures optional parser behavior. The zero value matches Parse.
+type ParseOptions struct {
+	// RejectDuplicateKeys fails parsing when any JSON object repeats a key
+	RejectDuplicateKeys bool
+	// UseNumber decodes JSON numbers as json.Number instead of float64, preserving integers
+	UseNumber bool
+	// StripRules discards every rule so all elements parse without conditional behavior
+	StripRules bool
+	// SkipInvalidElements replaces child elements that fail to parse with an InvalidElement
+	// instead of failing the whole parse
+	SkipInvalidElements bool
+	// AllowCustomConditions wraps unknown condition types in a CustomCondition instead of failing
+	AllowCustomConditions bool
+	// StrictConditionFields fails parsing when a condition has keys not defined for its type
+	StrictConditionFields bool
+	// AllowKeyedElements accepts "elements" given as an object keyed by id, parsing its values in key order
+	AllowKeyedElements bool
+	// ExpandShorthandRules converts options.showOn {scope, value} into a SHOW rule with a LEAF condition
+	ExpandShorthandRules bool
+	// PreserveRaw keeps the decoded JSON object of every element in BaseUISchemaElement.Raw
+	PreserveRaw bool
+	// TypedSchema additionally decodes the data schema into AST.TypedSchema
+	TypedSchema bool
+	// ConditionParsers parse custom condition types by name, taking precedence over RegisterConditionParser
+	ConditionParsers map[string]ConditionParser
+	// CaseInsensitiveTypes matches standard element types regardless of case, so "control" parses as
+	// a Control. Type keeps the original spelling; NormalizeTypes rewrites it.
+	CaseInsensitiveTypes bool
+}
+
+// ConditionParser builds a typed condition from the raw data of a custom condition type
+type ConditionParser func(data map[string]any) (Condition, error)
+
+// conditionParsers holds the parsers added with RegisterConditionParser
+var (
+	conditionParsersMu sync.RWMutex
+	conditionParsers   = map[string]ConditionParser{}
+)
+
+// RegisterConditionParser makes every parse hand conditions of typeName to fn instead of failing
+// with ErrUnknownConditionType. Built-in condition types cannot be overridden.
+func RegisterConditionParser(typeName string, fn ConditionParser) {
+	conditionParsersMu.Lock()
+	defer conditionParsersMu.Unlock()
+
+	conditionParsers[typeName] = fn
+}
+
+// conditionParser looks up a custom condition parser, preferring the parse options over the registry
+func (p *parser) conditionParser(typeName string) (ConditionParser, bool) {
+	if fn, ok := p.opts.ConditionParsers[typeName]; ok {
+		return fn, true
+	}
+
+	conditionParsersMu.RLock()
+	defer conditionParsersMu.RUnlock()
+
+	fn, ok := conditionParsers[typeName]
+
+	return fn, ok
+}
+
+// parser holds the options for a single parse run
+type parser struct {
+	opts ParseOptions
+}
+
 // Parse parses JSON Forms UI schema and data schema into an AST
 func Parse(uiSchemaJSON, schemaJSON []byte) (*AST, error) {
+	return ParseWithOptions(uiSchemaJSON, schemaJSON, ParseOptions{})
+}
+
+// ParseWithOptions parses JSON Forms UI schema and data schema into an AST using the given options
+func ParseWithOptions(uiSchemaJSON, schemaJSON []byte, opts ParseOptions) (*AST, error) {
+	p := &parser{opts: opts}
+
 	// Parse UI Schema
-	uiSchema, err := parseUISchema(uiSchemaJSON)
+	uiSchema, root, err := p.parseUISchema(uiSchemaJSON)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse UI schema: %w", err)
 	}
 
-	// Parse Data Schema (stored as raw any)
+	uiSchemas, err := p.parseScopedUISchemas(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse uischemas: %w", err)
+	}
+
+	// Parse Data Schema (stored as raw any), falling back to a schema embedded in the UI schema root
 	var schema any
 	if len(schemaJSON) > 0 {
-		if err := json.Unmarshal(schemaJSON, &schema); err != nil {
+		if err := p.decode(schemaJSON, &schema); err != nil {
 			return nil, fmt.Errorf("failed to parse data schema: %w", err)
 		}
+	} else if embeddedSchema, ok := root["schema"].(map[string]any); ok {
+		schema = embeddedSchema
 	}
 
-	return &AST{
-		UISchema: uiSchema,
-		Schema:   schema,
-	}, nil
+	ast := &AST{
+		UISchema:  uiSchema,
+		Schema:    schema,
+		UISchemas: uiSchemas,
+	}
+
+	if opts.TypedSchema && schema != nil {
+		if ast.TypedSchema, err = decodeTypedSchema(schema); err != nil {
+			return nil, fmt.Errorf("failed to decode typed data schema: %w", err)
+		}
+	}
+
+	return ast, nil
 }
 
-// parseUISchema parses the UI schema JSON into a UISchemaElement
-func parseUISchema(data []byte) (UISchemaElement, error) {
+// decode unmarshals JSON, applying the configured duplicate key check and number mode
+func (p *parser) decode(data []byte, v any) error {
+	if p.opts.RejectDuplicateKeys {
+		if err := checkDuplicateKeys(data); err != nil {
+			return err
+		}
+	}
+
+	if !p.opts.UseNumber {
+		return json.Unmarshal(data, v)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return ErrTrailingData
+	}
+
+	return nil
+}
+
+// parseUISchema parses the UI schema JSON into a UISchemaElement, also returning the raw root
+// object for the keys it may carry beside the element, such as an embedded "schema"
+func (p *parser) parseUISchema(data []byte) (UISchemaElement, map[string]any, error) {
 	var raw map[string]any
-	if err := json.Unmarshal(data, &raw); err != nil {
-		return nil, fmt.Errorf("invalid JSON: %w", err)
+	if err := p.decode(data, &raw); err != nil {
+		return nil, nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	element, err := p.parseUISchemaElement(raw)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return parseUISchemaElement(raw)
+	return element, raw, nil
+}
+
+// parseScopedUISchemas parses the "uischemas" registry on the UI schema root, if present
+func (p *parser) parseScopedUISchemas(root map[string]any) ([]ScopedUISchema, error) {
+	data, ok := root["uischemas"]
+	if !ok {
+		return nil, nil
+	}
+
+	entries, ok := data.([]any)
+	if !ok {
+		return nil, ErrInvalidUISchemas
+	}
+
+	uiSchemas := make([]ScopedUISchema, 0, len(entries))
+
+	for i, entryData := range entries {
+		entry, ok := entryData.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("entry %d: %w", i, ErrInvalidUISchemas)
+		}
+
+		elementData, ok := entry["uischema"].(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("entry %d: %w", i, ErrInvalidUISchemas)
+		}
+
+		element, err := p.parseUISchemaElement(elementData)
+		if err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i, err)
+		}
+
+		tester, _ := entry["tester"].(string)
+		scope, _ := entry["scope"].(string)
+
+		uiSchemas = append(uiSchemas, ScopedUISchema{Tester: tester, Scope: scope, UISchema: element})
+	}
+
+	return uiSchemas, nil
 }
 
 // parseUISchemaElement recursively parses a UI schema element
-func parseUISchemaElement(data map[string]any) (UISchemaElement, error) {
+func (p *parser) parseUISchemaElement(data map[string]any) (UISchemaElement, error) {
 	elementType, ok := data["type"].(string)
 	if !ok {
 		return nil, ErrMissingTypeField
 	}
 
 	// Parse common base fields
-	base, err := parseBaseElement(data)
+	base, err := p.parseBaseElement(data)
 	if err != nil {
 		return nil, err
 	}
 
 	// Parse specific element types
-	switch elementType {
+	switch p.standardType(elementType) {
 	case "Control":
-		return parseControl(data, base)
+		return p.parseControl(data, base)
 	case "VerticalLayout":
-		return parseVerticalLayout(data, base)
+		return p.parseVerticalLayout(data, base)
 	case "HorizontalLayout":
-		return parseHorizontalLayout(data, base)
+		return p.parseHorizontalLayout(data, base)
 	case "Group":
-		return parseGroup(data, base)
+		return p.parseGroup(data, base)
 	case "Categorization":
-		return parseCategorization(data, base)
+		return p.parseCategorization(data, base)
 	case "Category":
-		return parseCategory(data, base)
+		return p.parseCategory(data, base)
 	case "Label":
-		return parseLabel(data, base)
+		return p.parseLabel(data, base)
+	case "ListWithDetail":
+		return p.parseListWithDetail(data, base)
 	default:
 		// Create a CustomElement for unknown element types
-		return parseCustomElement(data, base), nil
+		return p.parseCustomElement(data, base), nil
+	}
+}
+
+// baseElementKeys are the keys parseBaseElement consumes for every element type
+var baseElementKeys = []string{"type", "rule", "rules", "options", "i18n"}
+
+// typedElementKeys are the further keys each element parser consumes; anything else, such as a
+// label on a VerticalLayout, is kept in Extra. Custom elements keep every key in RawData as well.
+var typedElementKeys = map[string][]string{
+	"Control":          {"scope", "label"},
+	"VerticalLayout":   {"elements"},
+	"HorizontalLayout": {"elements"},
+	"Group":            {"label", "elements"},
+	"Categorization":   {"label", "elements"},
+	"Category":         {"label", "elements"},
+	"Label":            {"text", "show"},
+	"ListWithDetail":   {"scope"},
+}
+
+// standardType returns the standard element type elementType names, ignoring case when
+// CaseInsensitiveTypes is set. Other types are returned unchanged.
+func (p *parser) standardType(elementType string) string {
+	if !p.opts.CaseInsensitiveTypes {
+		return elementType
 	}
+
+	for standard := range typedElementKeys {
+		if strings.EqualFold(standard, elementType) {
+			return standard
+		}
+	}
+
+	return elementType
+}
+
+// isKnownElementKey reports whether the parser for elementType consumes key
+func isKnownElementKey(elementType, key string) bool {
+	if slices.Contains(baseElementKeys, key) {
+		return true
+	}
+
+	keys, standard := typedElementKeys[elementType]
+	if !standard {
+		keys = []string{"elements"}
+	}
+
+	return slices.Contains(keys, key)
 }
 
 // parseBaseElement parses common fields shared by all UI schema elements
-func parseBaseElement(data map[string]any) (BaseUISchemaElement, error) {
+func (p *parser) parseBaseElement(data map[string]any) (BaseUISchemaElement, error) {
+	elementType, ok := data["type"].(string)
+	if !ok {
+		return BaseUISchemaElement{}, ErrMissingTypeField
+	}
+
 	base := BaseUISchemaElement{
-		Type: data["type"].(string),
+		Type: elementType,
 	}
 
 	// Parse optional rule
-	if ruleData, ok := data["rule"].(map[string]any); ok {
-		rule, err := parseRule(ruleData)
+	if ruleData, ok := data["rule"].(map[string]any); ok && !p.opts.StripRules {
+		rule, err := p.parseRule(ruleData)
 		if err != nil {
 			return base, fmt.Errorf("failed to parse rule: %w", err)
 		}
@@ -110,21 +338,113 @@ func parseBaseElement(data map[string]any) (BaseUISchemaElement, error) {
 		base.Rule = rule
 	}
 
-	// Parse optional options
+	// Parse optional rules array, a non-standard extension allowing several rules per element
+	if rulesData, ok := data["rules"]; ok && !p.opts.StripRules {
+		rules, err := p.parseRules(rulesData)
+		if err != nil {
+			return base, err
+		}
+
+		if base.Rule != nil {
+			rules = append([]*Rule{base.Rule}, rules...)
+		}
+
+		base.Rules = rules
+		if len(rules) > 0 {
+			base.Rule = rules[0]
+		}
+	}
+
+	// Parse optional options, keeping null values as explicit nil entries
 	if options, ok := data["options"].(map[string]any); ok {
 		base.Options = options
 	}
 
+	if _, ok := base.Options["showOn"]; ok && p.opts.ExpandShorthandRules && !p.opts.StripRules {
+		if err := expandShowOn(&base); err != nil {
+			return base, err
+		}
+	}
+
 	// Parse optional i18n
 	if i18n, ok := data["i18n"].(string); ok {
 		base.I18n = &i18n
 	}
 
+	if p.opts.PreserveRaw {
+		base.Raw = data
+	}
+
+	// Preserve unrecognized keys such as "$version" or "metadata"
+	for key, value := range data {
+		if isKnownElementKey(p.standardType(elementType), key) {
+			continue
+		}
+
+		if base.Extra == nil {
+			base.Extra = map[string]any{}
+		}
+
+		base.Extra[key] = value
+	}
+
 	return base, nil
 }
 
+// expandShowOn replaces options.showOn with an equivalent SHOW rule, added after any existing rules
+func expandShowOn(base *BaseUISchemaElement) error {
+	showOn, _ := base.Options["showOn"].(map[string]any)
+
+	scope, ok := showOn["scope"].(string)
+	if !ok {
+		return ErrInvalidShowOn
+	}
+
+	value, ok := showOn["value"]
+	if !ok {
+		return ErrInvalidShowOn
+	}
+
+	rule := &Rule{
+		Effect: RuleEffectSHOW,
+		Condition: &LeafCondition{
+			Type:          "LEAF",
+			Scope:         scope,
+			ExpectedValue: value,
+			Relative:      isRelativeScope(scope),
+		},
+	}
+
+	if base.Rule == nil {
+		base.Rule = rule
+	} else {
+		base.Rules = append(base.GetRules(), rule)
+	}
+
+	base.Options = withoutOption(base.Options, "showOn")
+
+	return nil
+}
+
+// withoutOption returns a copy of options without key, or nil when nothing else remains.
+// Copying leaves the decoded input untouched.
+func withoutOption(options map[string]any, key string) map[string]any {
+	result := make(map[string]any, len(options))
+	for name, option := range options {
+		if name != key {
+			result[name] = option
+		}
+	}
+
+	if len(result) == 0 {
+		return nil
+	}
+
+	return result
+}
+
 // parseControl parses a Control element
-func parseControl(data map[string]any, base BaseUISchemaElement) (*Control, error) {
+func (p *parser) parseControl(data map[string]any, base BaseUISchemaElement) (*Control, error) {
 	scope, ok := data["scope"].(string)
 	if !ok {
 		return nil, ErrControlMissingScope
@@ -139,12 +459,63 @@ func parseControl(data map[string]any, base BaseUISchemaElement) (*Control, erro
 		control.Label = label
 	}
 
+	detail, err := p.parseDetail(control.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	control.Detail = detail
+
 	return control, nil
 }
 
+// parseListWithDetail parses a ListWithDetail element
+func (p *parser) parseListWithDetail(data map[string]any, base BaseUISchemaElement) (*ListWithDetail, error) {
+	scope, ok := data["scope"].(string)
+	if !ok {
+		return nil, ErrListWithDetailMissingScope
+	}
+
+	list := &ListWithDetail{
+		BaseUISchemaElement: base,
+		Scope:               scope,
+	}
+
+	detail, err := p.parseDetail(list.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	list.Detail = detail
+
+	return list, nil
+}
+
+// parseDetail parses an inline options.detail layout for array elements, returning nil when there
+// is none. Options keeps the raw object; encoders write the parsed Detail over it. With
+// SkipInvalidElements set, a broken detail yields an InvalidElement placeholder.
+func (p *parser) parseDetail(options map[string]any) (UISchemaElement, error) {
+	detailData, ok := options["detail"].(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+
+	detail, err := p.parseUISchemaElement(detailData)
+	if err != nil {
+		err = fmt.Errorf("failed to parse detail: %w", err)
+		if p.opts.SkipInvalidElements {
+			return &InvalidElement{Err: err, RawData: detailData}, nil
+		}
+
+		return nil, err
+	}
+
+	return detail, nil
+}
+
 // parseVerticalLayout parses a VerticalLayout element
-func parseVerticalLayout(data map[string]any, base BaseUISchemaElement) (*VerticalLayout, error) {
-	elements, err := parseElementsArray(data)
+func (p *parser) parseVerticalLayout(data map[string]any, base BaseUISchemaElement) (*VerticalLayout, error) {
+	elements, err := p.parseElementsArray(data)
 	if err != nil {
 		return nil, err
 	}
@@ -156,8 +527,8 @@ func parseVerticalLayout(data map[string]any, base BaseUISchemaElement) (*Vertic
 }
 
 // parseHorizontalLayout parses a HorizontalLayout element
-func parseHorizontalLayout(data map[string]any, base BaseUISchemaElement) (*HorizontalLayout, error) {
-	elements, err := parseElementsArray(data)
+func (p *parser) parseHorizontalLayout(data map[string]any, base BaseUISchemaElement) (*HorizontalLayout, error) {
+	elements, err := p.parseElementsArray(data)
 	if err != nil {
 		return nil, err
 	}
@@ -169,13 +540,13 @@ func parseHorizontalLayout(data map[string]any, base BaseUISchemaElement) (*Hori
 }
 
 // parseGroup parses a Group element
-func parseGroup(data map[string]any, base BaseUISchemaElement) (*Group, error) {
+func (p *parser) parseGroup(data map[string]any, base BaseUISchemaElement) (*Group, error) {
 	label, ok := data["label"].(string)
 	if !ok {
 		return nil, ErrGroupMissingLabel
 	}
 
-	elements, err := parseElementsArray(data)
+	elements, err := p.parseElementsArray(data)
 	if err != nil {
 		return nil, err
 	}
@@ -188,8 +559,8 @@ func parseGroup(data map[string]any, base BaseUISchemaElement) (*Group, error) {
 }
 
 // parseCategorization parses a Categorization element
-func parseCategorization(data map[string]any, base BaseUISchemaElement) (*Categorization, error) {
-	elementsData, ok := data["elements"].([]any)
+func (p *parser) parseCategorization(data map[string]any, base BaseUISchemaElement) (*Categorization, error) {
+	elementsData, ok := p.elementsData(data)
 	if !ok {
 		return nil, ErrCategorizationMissingElements
 	}
@@ -197,14 +568,9 @@ func parseCategorization(data map[string]any, base BaseUISchemaElement) (*Catego
 	var elements []CategoryElement
 
 	for i, elemData := range elementsData {
-		elemMap, ok := elemData.(map[string]any)
-		if !ok {
-			return nil, fmt.Errorf("element %d: %w", i, ErrElementNotObject)
-		}
-
-		elem, err := parseUISchemaElement(elemMap)
+		elem, err := p.parseChildElement(i, elemData)
 		if err != nil {
-			return nil, fmt.Errorf("element %d: %w", i, err)
+			return nil, err
 		}
 
 		// Ensure element is a Category or Categorization (skip custom elements in categorizations)
@@ -230,13 +596,13 @@ func parseCategorization(data map[string]any, base BaseUISchemaElement) (*Catego
 }
 
 // parseCategory parses a Category element
-func parseCategory(data map[string]any, base BaseUISchemaElement) (*Category, error) {
+func (p *parser) parseCategory(data map[string]any, base BaseUISchemaElement) (*Category, error) {
 	label, ok := data["label"].(string)
 	if !ok {
 		return nil, ErrCategoryMissingLabel
 	}
 
-	elements, err := parseElementsArray(data)
+	elements, err := p.parseElementsArray(data)
 	if err != nil {
 		return nil, err
 	}
@@ -249,20 +615,29 @@ func parseCategory(data map[string]any, base BaseUISchemaElement) (*Category, er
 }
 
 // parseLabel parses a Label element
-func parseLabel(data map[string]any, base BaseUISchemaElement) (*Label, error) {
+func (p *parser) parseLabel(data map[string]any, base BaseUISchemaElement) (*Label, error) {
 	text, ok := data["text"].(string)
 	if !ok {
 		return nil, ErrLabelMissingText
 	}
 
-	return &Label{
+	label := &Label{
 		BaseUISchemaElement: base,
 		Text:                text,
-	}, nil
+	}
+
+	// A top-level show flag takes precedence over options.show
+	if show, ok := data["show"].(bool); ok {
+		label.Show = &show
+	} else if show, ok := base.Options["show"].(bool); ok {
+		label.Show = &show
+	}
+
+	return label, nil
 }
 
 // parseCustomElement parses an unknown/custom element type
-func parseCustomElement(data map[string]any, base BaseUISchemaElement) *CustomElement {
+func (p *parser) parseCustomElement(data map[string]any, base BaseUISchemaElement) *CustomElement {
 	custom := &CustomElement{
 		BaseUISchemaElement: base,
 		RawData:             data,
@@ -270,7 +645,7 @@ func parseCustomElement(data map[string]any, base BaseUISchemaElement) *CustomEl
 
 	// Try to parse child elements if they exist
 	if _, hasElements := data["elements"]; hasElements {
-		elements, err := parseElementsArray(data)
+		elements, err := p.parseElementsArray(data)
 		if err == nil {
 			custom.Elements = elements
 		}
@@ -281,8 +656,8 @@ func parseCustomElement(data map[string]any, base BaseUISchemaElement) *CustomEl
 }
 
 // parseElementsArray parses the 'elements' array common to many layout types
-func parseElementsArray(data map[string]any) ([]UISchemaElement, error) {
-	elementsData, ok := data["elements"].([]any)
+func (p *parser) parseElementsArray(data map[string]any) ([]UISchemaElement, error) {
+	elementsData, ok := p.elementsData(data)
 	if !ok {
 		return nil, ErrMissingElements
 	}
@@ -290,24 +665,116 @@ func parseElementsArray(data map[string]any) ([]UISchemaElement, error) {
 	var elements []UISchemaElement
 
 	for i, elemData := range elementsData {
-		elemMap, ok := elemData.(map[string]any)
+		elem, err := p.parseChildElement(i, elemData)
+		if err != nil {
+			return nil, err
+		}
+
+		elements = append(elements, elem)
+	}
+
+	return elements, nil
+}
+
+// elementsData returns an element's "elements" array. With AllowKeyedElements set, an object of
+// elements keyed by id is accepted too and returned ordered by key.
+func (p *parser) elementsData(data map[string]any) ([]any, bool) {
+	switch elements := data["elements"].(type) {
+	case []any:
+		return elements, true
+	case map[string]any:
+		if !p.opts.AllowKeyedElements {
+			return nil, false
+		}
+
+		keys := make([]string, 0, len(elements))
+		for key := range elements {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		ordered := make([]any, 0, len(keys))
+		for _, key := range keys {
+			ordered = append(ordered, elements[key])
+		}
+
+		return ordered, true
+	default:
+		return nil, false
+	}
+}
+
+// parseChildElement parses the element at index i of an elements array. With SkipInvalidElements
+// set, a failure yields an InvalidElement placeholder instead of an error.
+func (p *parser) parseChildElement(i int, data any) (UISchemaElement, error) {
+	elemMap, ok := data.(map[string]any)
+
+	err := ErrElementNotObject
+	if ok {
+		elem, parseErr := p.parseUISchemaElement(elemMap)
+		if parseErr == nil {
+			return elem, nil
+		}
+
+		err = parseErr
+	}
+
+	err = fmt.Errorf("element %d: %w", i, err)
+	if p.opts.SkipInvalidElements {
+		return &InvalidElement{Err: err, RawData: elemMap}, nil
+	}
+
+	return nil, err
+}
+
+// splitChildren returns a copy of an element's data with an empty elements array, so the element
+// can be parsed on its own, together with the original children. Data without an elements array
+// is returned unchanged.
+func splitChildren(data map[string]any) (map[string]any, []any) {
+	children, ok := data["elements"].([]any)
+	if !ok {
+		return data, nil
+	}
+
+	shallow := make(map[string]any, len(data))
+	for key, value := range data {
+		shallow[key] = value
+	}
+
+	shallow["elements"] = []any{}
+
+	return shallow, children
+}
+
+// parseRules parses a "rules" array of Rule objects
+func (p *parser) parseRules(data any) ([]*Rule, error) {
+	rulesData, ok := data.([]any)
+	if !ok {
+		return nil, ErrInvalidRules
+	}
+
+	rules := make([]*Rule, 0, len(rulesData))
+
+	for i, ruleData := range rulesData {
+		ruleMap, ok := ruleData.(map[string]any)
 		if !ok {
-			return nil, fmt.Errorf("element %d: %w", i, ErrElementNotObject)
+			return nil, fmt.Errorf("rule %d: %w", i, ErrInvalidRules)
 		}
 
-		elem, err := parseUISchemaElement(elemMap)
+		rule, err := p.parseRule(ruleMap)
 		if err != nil {
-			return nil, fmt.Errorf("element %d: %w", i, err)
+			return nil, fmt.Errorf("failed to parse rule %d: %w", i, err)
 		}
 
-		elements = append(elements, elem)
+		rules = append(rules, rule)
 	}
 
-	return elements, nil
+	return rules, nil
 }
 
 // parseRule parses a Rule object
-func parseRule(data map[string]any) (*Rule, error) {
+func (p *parser) parseRule(data map[string]any) (*Rule, error) {
 	effect, ok := data["effect"].(string)
 	if !ok {
 		return nil, ErrRuleMissingEffect
@@ -318,7 +785,7 @@ func parseRule(data map[string]any) (*Rule, error) {
 		return nil, ErrRuleMissingCondition
 	}
 
-	condition, err := parseCondition(conditionData)
+	condition, err := p.parseCondition(conditionData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse condition: %w", err)
 	}
@@ -330,27 +797,74 @@ func parseRule(data map[string]any) (*Rule, error) {
 }
 
 // parseCondition parses a Condition object
-func parseCondition(data map[string]any) (Condition, error) {
+func (p *parser) parseCondition(data map[string]any) (Condition, error) {
 	conditionType, _ := data["type"].(string)
 
+	if p.opts.StrictConditionFields {
+		if err := checkConditionFields(conditionType, data); err != nil {
+			return nil, err
+		}
+	}
+
 	// Determine condition type
 	switch conditionType {
 	case "LEAF":
-		return parseLeafCondition(data)
+		return p.parseLeafCondition(data)
 	case "AND":
-		return parseAndCondition(data)
+		return p.parseAndCondition(data)
 	case "OR":
-		return parseOrCondition(data)
+		return p.parseOrCondition(data)
 	case "SCHEMA_BASED", "":
 		// Default to SCHEMA_BASED if type is not specified
-		return parseSchemaBasedCondition(data)
+		return p.parseSchemaBasedCondition(data)
 	default:
+		if fn, ok := p.conditionParser(conditionType); ok {
+			return fn(data)
+		}
+
+		if p.opts.AllowCustomConditions {
+			return &CustomCondition{RawData: data}, nil
+		}
+
 		return nil, fmt.Errorf("%w: %s", ErrUnknownConditionType, conditionType)
 	}
 }
 
+// conditionFields lists the keys each condition type accepts, with "" standing for SCHEMA_BASED
+var conditionFields = map[string][]string{
+	"LEAF":         {"type", "scope", "expectedValue"},
+	"AND":          {"type", "conditions"},
+	"OR":           {"type", "conditions"},
+	"SCHEMA_BASED": {"type", "scope", "schema", "failWhenUndefined"},
+	"":             {"type", "scope", "schema", "failWhenUndefined"},
+}
+
+// checkConditionFields reports the first key not accepted by the condition type. Unknown types are left
+// to the type check.
+func checkConditionFields(conditionType string, data map[string]any) error {
+	fields, ok := conditionFields[conditionType]
+	if !ok {
+		return nil
+	}
+
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if !slices.Contains(fields, key) {
+			return fmt.Errorf("%w: %q", ErrUnknownConditionField, key)
+		}
+	}
+
+	return nil
+}
+
 // parseSchemaBasedCondition parses a SchemaBasedCondition
-func parseSchemaBasedCondition(data map[string]any) (*SchemaBasedCondition, error) {
+func (p *parser) parseSchemaBasedCondition(data map[string]any) (*SchemaBasedCondition, error) {
 	scope, ok := data["scope"].(string)
 	if !ok {
 		return nil, ErrSchemaConditionMissingScope
@@ -362,8 +876,9 @@ func parseSchemaBasedCondition(data map[string]any) (*SchemaBasedCondition, erro
 	}
 
 	condition := &SchemaBasedCondition{
-		Scope:  scope,
-		Schema: schema,
+		Scope:    scope,
+		Schema:   schema,
+		Relative: isRelativeScope(scope),
 	}
 
 	if condType, ok := data["type"].(string); ok {
@@ -378,7 +893,7 @@ func parseSchemaBasedCondition(data map[string]any) (*SchemaBasedCondition, erro
 }
 
 // parseLeafCondition parses a LeafCondition
-func parseLeafCondition(data map[string]any) (*LeafCondition, error) {
+func (p *parser) parseLeafCondition(data map[string]any) (*LeafCondition, error) {
 	scope, ok := data["scope"].(string)
 	if !ok {
 		return nil, ErrLeafConditionMissingScope
@@ -393,11 +908,12 @@ func parseLeafCondition(data map[string]any) (*LeafCondition, error) {
 		Type:          "LEAF",
 		Scope:         scope,
 		ExpectedValue: expectedValue,
+		Relative:      isRelativeScope(scope),
 	}, nil
 }
 
 // parseAndCondition parses an AndCondition
-func parseAndCondition(data map[string]any) (*AndCondition, error) {
+func (p *parser) parseAndCondition(data map[string]any) (*AndCondition, error) {
 	conditionsData, ok := data["conditions"].([]any)
 	if !ok {
 		return nil, ErrAndConditionMissingConditions
@@ -411,7 +927,7 @@ func parseAndCondition(data map[string]any) (*AndCondition, error) {
 			return nil, fmt.Errorf("condition %d: %w", i, ErrElementNotObject)
 		}
 
-		cond, err := parseCondition(condMap)
+		cond, err := p.parseCondition(condMap)
 		if err != nil {
 			return nil, fmt.Errorf("condition %d: %w", i, err)
 		}
@@ -426,7 +942,7 @@ func parseAndCondition(data map[string]any) (*AndCondition, error) {
 }
 
 // parseOrCondition parses an OrCondition
-func parseOrCondition(data map[string]any) (*OrCondition, error) {
+func (p *parser) parseOrCondition(data map[string]any) (*OrCondition, error) {
 	conditionsData, ok := data["conditions"].([]any)
 	if !ok {
 		return nil, ErrOrConditionMissingConditions
@@ -440,7 +956,7 @@ func parseOrCondition(data map[string]any) (*OrCondition, error) {
 			return nil, fmt.Errorf("condition %d: %w", i, ErrElementNotObject)
 		}
 
-		cond, err := parseCondition(condMap)
+		cond, err := p.parseCondition(condMap)
 		if err != nil {
 			return nil, fmt.Errorf("condition %d: %w", i, err)
 		}
@@ -453,3 +969,57 @@ func parseOrCondition(data map[string]any) (*OrCondition, error) {
 		Conditions: conditions,
 	}, nil
 }
+
+// checkDuplicateKeys scans JSON tokens and reports the first object that repeats a key
+func checkDuplicateKeys(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	return checkDuplicateKeysValue(decoder, "")
+}
+
+// checkDuplicateKeysValue consumes one JSON value from the decoder, checking nested objects for repeated keys
+func checkDuplicateKeysValue(decoder *json.Decoder, path string) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return nil
+	}
+
+	switch delim {
+	case '{':
+		seen := map[string]bool{}
+
+		for decoder.More() {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+
+			key, _ := keyToken.(string)
+			if seen[key] {
+				return &PathError{Path: path, Err: fmt.Errorf("%w: %q", ErrDuplicateKey, key)}
+			}
+
+			seen[key] = true
+
+			if err := checkDuplicateKeysValue(decoder, childPath(path, key)); err != nil {
+				return err
+			}
+		}
+	case '[':
+		for i := 0; decoder.More(); i++ {
+			if err := checkDuplicateKeysValue(decoder, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Consume the closing delimiter
+	_, err = decoder.Token()
+
+	return err
+}
@@ -1,6 +1,8 @@
 package jsonforms
 
 import (
+	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -768,4 +770,1109 @@ func TestVisitorAllElements(t *testing.T) {
 	assert.Equal(t, 6, visitor.ControlCount)
 	assert.Equal(t, 1, visitor.LabelCount)
 	assert.Equal(t, 2, visitor.CustomElementCount)
+
+	assert.Equal(t, map[string]int{
+		"VerticalLayout":   1,
+		"HorizontalLayout": 1,
+		"Group":            1,
+		"Categorization":   1,
+		"Category":         2,
+		"Control":          6,
+		"Label":            1,
+		"Notice":           1,
+		"Markdown":         1,
+	}, CountByType(result.UISchema))
+}
+
+func TestParseDuplicateKeysTolerated(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{
+				"type": "Control",
+				"scope": "#/properties/name",
+				"scope": "#/properties/email"
+			}
+		]
+	}`)
+
+	result, err := Parse(uiSchema, nil)
+	require.NoError(t, err)
+
+	layout, ok := result.UISchema.(*VerticalLayout)
+	require.True(t, ok, "Expected VerticalLayout, got %T", result.UISchema)
+
+	control, ok := layout.Elements[0].(*Control)
+	require.True(t, ok, "Expected Control, got %T", layout.Elements[0])
+
+	assert.Equal(t, "#/properties/email", control.Scope)
+}
+
+func TestParseDuplicateKeysRejected(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{
+				"type": "Control",
+				"scope": "#/properties/name",
+				"scope": "#/properties/email"
+			}
+		]
+	}`)
+
+	_, err := ParseWithOptions(uiSchema, nil, ParseOptions{RejectDuplicateKeys: true})
+	require.ErrorIs(t, err, ErrDuplicateKey)
+	assert.Contains(t, err.Error(), `at elements[0]: duplicate JSON key: "scope"`)
+}
+
+func TestVisitorDescendsIntoControlDetail(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{
+				"type": "Control",
+				"scope": "#/properties/contacts",
+				"options": {
+					"detail": {
+						"type": "HorizontalLayout",
+						"elements": [
+							{
+								"type": "Control",
+								"scope": "#/properties/name"
+							},
+							{
+								"type": "Control",
+								"scope": "#/properties/phone"
+							}
+						]
+					}
+				}
+			}
+		]
+	}`)
+
+	result, err := Parse(uiSchema, nil)
+	require.NoError(t, err)
+
+	layout, ok := result.UISchema.(*VerticalLayout)
+	require.True(t, ok, "Expected VerticalLayout, got %T", result.UISchema)
+
+	control, ok := layout.Elements[0].(*Control)
+	require.True(t, ok, "Expected Control, got %T", layout.Elements[0])
+
+	_, ok = control.Detail.(*HorizontalLayout)
+	require.True(t, ok, "Expected HorizontalLayout detail, got %T", control.Detail)
+
+	visitor := &countingVisitor{}
+
+	err = Walk(result.UISchema, visitor)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, visitor.ControlCount)
+	assert.Equal(t, 1, visitor.HorizontalLayoutCount)
+}
+
+func TestControlDetailEncoding(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "Control",
+		"scope": "#/properties/contacts",
+		"options": {
+			"showSortButtons": true,
+			"detail": {
+				"type": "VerticalLayout",
+				"elements": [
+					{
+						"type": "Control",
+						"scope": "#/properties/phone",
+						"rule": {"effect": "HIDE", "condition": {"type": "LEAF", "scope": "#/properties/hidden", "expectedValue": true}}
+					}
+				]
+			}
+		}
+	}`), nil)
+	require.NoError(t, err)
+
+	control, ok := result.UISchema.(*Control)
+	require.True(t, ok, "Expected Control, got %T", result.UISchema)
+	assert.Contains(t, control.Options, "detail")
+	assert.Equal(t, true, control.Options["showSortButtons"])
+	assert.Equal(t, []UISchemaElement{control}, ElementsWithOption(control, "detail"))
+
+	StripRules(control)
+	assert.False(t, HasRules(control))
+
+	data, err := json.Marshal(control)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "Control",
+		"scope": "#/properties/contacts",
+		"options": {
+			"showSortButtons": true,
+			"detail": {
+				"type": "VerticalLayout",
+				"elements": [{"type": "Control", "scope": "#/properties/phone"}]
+			}
+		}
+	}`, string(data))
+}
+
+func TestParseInvalidDetail(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "Control",
+		"scope": "#/properties/contacts",
+		"options": {"detail": {"type": "Group", "elements": []}}
+	}`)
+
+	_, err := Parse(uiSchema, nil)
+	require.ErrorIs(t, err, ErrGroupMissingLabel)
+
+	result, err := ParseWithOptions(uiSchema, nil, ParseOptions{SkipInvalidElements: true})
+	require.NoError(t, err)
+
+	control, ok := result.UISchema.(*Control)
+	require.True(t, ok, "Expected Control, got %T", result.UISchema)
+
+	invalid, ok := control.Detail.(*InvalidElement)
+	require.True(t, ok, "Expected InvalidElement detail, got %T", control.Detail)
+	require.ErrorIs(t, invalid.Err, ErrGroupMissingLabel)
+	assert.Equal(t, map[string]any{"type": "Group", "elements": []any{}}, invalid.RawData)
+}
+
+func TestParseUnknownConditionType(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "Control",
+		"scope": "#/properties/discount",
+		"rule": {
+			"effect": "SHOW",
+			"condition": {
+				"type": "RANGE",
+				"scope": "#/properties/age",
+				"min": 18
+			}
+		}
+	}`)
+
+	_, err := Parse(uiSchema, nil)
+	require.ErrorIs(t, err, ErrUnknownConditionType)
+
+	result, err := ParseWithOptions(uiSchema, nil, ParseOptions{AllowCustomConditions: true})
+	require.NoError(t, err)
+
+	control, ok := result.UISchema.(*Control)
+	require.True(t, ok, "Expected Control, got %T", result.UISchema)
+
+	condition, ok := control.Rule.Condition.(*CustomCondition)
+	require.True(t, ok, "Expected CustomCondition, got %T", control.Rule.Condition)
+
+	assert.Equal(t, "RANGE", condition.GetType())
+	assert.Equal(t, float64(18), condition.RawData["min"])
+}
+
+func TestParseBatch(t *testing.T) {
+	inputs := []ParseInput{
+		{ID: "name", UISchema: []byte(`{"type": "Control", "scope": "#/properties/name"}`)},
+		{ID: "broken", UISchema: []byte(`{invalid json}`)},
+		{ID: "label", UISchema: []byte(`{"type": "Label", "text": "Hello"}`), Schema: []byte(`{"type": "object"}`)},
+		{ID: "missing-scope", UISchema: []byte(`{"type": "Control"}`)},
+	}
+
+	results := ParseBatch(inputs, 2)
+	require.Len(t, results, 4)
+
+	byID := map[string]ParseResult{}
+	for _, result := range results {
+		byID[result.ID] = result
+	}
+
+	require.NoError(t, byID["name"].Err)
+	assert.IsType(t, &Control{}, byID["name"].AST.UISchema)
+
+	require.Error(t, byID["broken"].Err)
+	assert.Nil(t, byID["broken"].AST)
+
+	require.NoError(t, byID["label"].Err)
+	assert.NotNil(t, byID["label"].AST.Schema)
+
+	require.ErrorIs(t, byID["missing-scope"].Err, ErrControlMissingScope)
+}
+
+func TestParseLabelShow(t *testing.T) {
+	hidden, err := Parse([]byte(`{"type": "Label", "text": "Hidden", "show": false}`), nil)
+	require.NoError(t, err)
+
+	label, ok := hidden.UISchema.(*Label)
+	require.True(t, ok, "Expected Label, got %T", hidden.UISchema)
+
+	if assert.NotNil(t, label.Show) {
+		assert.False(t, *label.Show)
+	}
+
+	shown, err := Parse([]byte(`{"type": "Label", "text": "Shown", "options": {"show": true}}`), nil)
+	require.NoError(t, err)
+
+	label, ok = shown.UISchema.(*Label)
+	require.True(t, ok, "Expected Label, got %T", shown.UISchema)
+
+	if assert.NotNil(t, label.Show) {
+		assert.True(t, *label.Show)
+	}
+
+	plain, err := Parse([]byte(`{"type": "Label", "text": "Plain"}`), nil)
+	require.NoError(t, err)
+
+	label, ok = plain.UISchema.(*Label)
+	require.True(t, ok, "Expected Label, got %T", plain.UISchema)
+
+	assert.Nil(t, label.Show)
+}
+
+func TestParseExtraKeys(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "Control",
+		"scope": "#/properties/name",
+		"label": "Name",
+		"options": {"trim": true},
+		"$version": "2.1",
+		"metadata": {"owner": "forms-team"}
+	}`)
+
+	result, err := Parse(uiSchema, nil)
+	require.NoError(t, err)
+
+	control, ok := result.UISchema.(*Control)
+	require.True(t, ok, "Expected Control, got %T", result.UISchema)
+
+	assert.Equal(t, map[string]any{
+		"$version": "2.1",
+		"metadata": map[string]any{"owner": "forms-team"},
+	}, control.Extra)
+
+	plain, err := Parse([]byte(`{"type": "Control", "scope": "#/properties/name"}`), nil)
+	require.NoError(t, err)
+
+	assert.Nil(t, plain.UISchema.(*Control).Extra)
+}
+
+func TestParseExtraKeysPerType(t *testing.T) {
+	uiSchema := `{
+		"type": "VerticalLayout",
+		"label": "Contact",
+		"elements": [
+			{"type": "Control", "scope": "#/properties/name", "text": "Full name"},
+			{"type": "Group", "label": "Address", "scope": "#/properties/address", "elements": []}
+		]
+	}`
+
+	result, err := Parse([]byte(uiSchema), nil)
+	require.NoError(t, err)
+
+	layout, ok := result.UISchema.(*VerticalLayout)
+	require.True(t, ok, "Expected VerticalLayout, got %T", result.UISchema)
+	assert.Equal(t, map[string]any{"label": "Contact"}, layout.Extra)
+
+	control, ok := layout.Elements[0].(*Control)
+	require.True(t, ok, "Expected Control, got %T", layout.Elements[0])
+	assert.Equal(t, map[string]any{"text": "Full name"}, control.Extra)
+
+	group, ok := layout.Elements[1].(*Group)
+	require.True(t, ok, "Expected Group, got %T", layout.Elements[1])
+	assert.Equal(t, map[string]any{"scope": "#/properties/address"}, group.Extra)
+
+	data, err := MarshalElement(result.UISchema)
+	require.NoError(t, err)
+	assert.JSONEq(t, uiSchema, string(data))
+}
+
+func TestParseUseNumber(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "Control",
+		"scope": "#/properties/discount",
+		"options": {"maxRows": 3, "step": 0.25},
+		"rule": {
+			"effect": "SHOW",
+			"condition": {
+				"type": "LEAF",
+				"scope": "#/properties/quantity",
+				"expectedValue": 5
+			}
+		}
+	}`)
+
+	result, err := ParseWithOptions(uiSchema, nil, ParseOptions{UseNumber: true})
+	require.NoError(t, err)
+
+	control, ok := result.UISchema.(*Control)
+	require.True(t, ok, "Expected Control, got %T", result.UISchema)
+
+	condition, ok := control.Rule.Condition.(*LeafCondition)
+	require.True(t, ok, "Expected LeafCondition, got %T", control.Rule.Condition)
+
+	assert.Equal(t, json.Number("5"), condition.ExpectedValue)
+
+	expected, ok := NumberAsInt64(condition.ExpectedValue)
+	require.True(t, ok)
+	assert.Equal(t, int64(5), expected)
+
+	step, ok := NumberAsFloat64(control.Options["step"])
+	require.True(t, ok)
+	assert.InDelta(t, 0.25, step, 0)
+
+	_, ok = NumberAsInt64(control.Options["step"])
+	assert.False(t, ok)
+
+	maxRows, ok := control.GetIntOption("maxRows")
+	require.True(t, ok)
+	assert.Equal(t, 3, maxRows)
+
+	_, err = ParseWithOptions([]byte(`{"type": "Label", "text": "x"} {}`), nil, ParseOptions{UseNumber: true})
+	require.ErrorIs(t, err, ErrTrailingData)
+}
+
+func TestWalkTypes(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{
+				"type": "Group",
+				"label": "Details",
+				"elements": [
+					{
+						"type": "Label",
+						"text": "Enter your details"
+					},
+					{
+						"type": "HorizontalLayout",
+						"elements": [
+							{
+								"type": "Control",
+								"scope": "#/properties/firstName"
+							}
+						]
+					}
+				]
+			},
+			{
+				"type": "Control",
+				"scope": "#/properties/email"
+			}
+		]
+	}`)
+
+	result, err := Parse(uiSchema, nil)
+	require.NoError(t, err)
+
+	var visited []string
+
+	err = WalkTypes(result.UISchema, map[string]bool{"Control": true, "Label": true}, func(element UISchemaElement) error {
+		visited = append(visited, element.GetType())
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Label", "Control", "Control"}, visited)
+}
+
+// skippingVisitor skips the children of groups while recording visited scopes
+type skippingVisitor struct {
+	BaseVisitor
+	Scopes []string
+}
+
+func (v *skippingVisitor) VisitGroup(g *Group) error {
+	return ErrSkipChildren
+}
+
+func (v *skippingVisitor) VisitControl(c *Control) error {
+	v.Scopes = append(v.Scopes, c.Scope)
+	return nil
+}
+
+func TestWalkSkipChildren(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{
+				"type": "Control",
+				"scope": "#/properties/before"
+			},
+			{
+				"type": "Group",
+				"label": "Skipped",
+				"elements": [
+					{
+						"type": "Control",
+						"scope": "#/properties/inside"
+					}
+				]
+			},
+			{
+				"type": "Control",
+				"scope": "#/properties/after"
+			}
+		]
+	}`)
+
+	result, err := Parse(uiSchema, nil)
+	require.NoError(t, err)
+
+	visitor := &skippingVisitor{}
+
+	err = Walk(result.UISchema, visitor)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"#/properties/before", "#/properties/after"}, visitor.Scopes)
+}
+
+func TestParseRulesArray(t *testing.T) {
+	single, err := Parse([]byte(`{
+		"type": "Control",
+		"scope": "#/properties/email",
+		"rule": {
+			"effect": "HIDE",
+			"condition": {"type": "LEAF", "scope": "#/properties/anonymous", "expectedValue": true}
+		}
+	}`), nil)
+	require.NoError(t, err)
+
+	assert.NotNil(t, single.UISchema.GetRule())
+	assert.Len(t, ElementRules(single.UISchema), 1)
+
+	multiple, err := Parse([]byte(`{
+		"type": "Control",
+		"scope": "#/properties/email",
+		"rules": [
+			{
+				"effect": "HIDE",
+				"condition": {"type": "LEAF", "scope": "#/properties/anonymous", "expectedValue": true}
+			},
+			{
+				"effect": "DISABLE",
+				"condition": {"type": "LEAF", "scope": "#/properties/locked", "expectedValue": true}
+			}
+		]
+	}`), nil)
+	require.NoError(t, err)
+
+	rules := ElementRules(multiple.UISchema)
+	require.Len(t, rules, 2)
+	assert.Equal(t, RuleEffectHIDE, rules[0].Effect)
+	assert.Equal(t, RuleEffectDISABLE, rules[1].Effect)
+	assert.Same(t, rules[0], multiple.UISchema.GetRule())
+
+	mixed, err := Parse([]byte(`{
+		"type": "Control",
+		"scope": "#/properties/email",
+		"rule": {
+			"effect": "SHOW",
+			"condition": {"type": "LEAF", "scope": "#/properties/subscribe", "expectedValue": true}
+		},
+		"rules": [
+			{
+				"effect": "DISABLE",
+				"condition": {"type": "LEAF", "scope": "#/properties/locked", "expectedValue": true}
+			}
+		]
+	}`), nil)
+	require.NoError(t, err)
+
+	rules = ElementRules(mixed.UISchema)
+	require.Len(t, rules, 2)
+	assert.Equal(t, RuleEffectSHOW, mixed.UISchema.GetRule().Effect)
+	assert.Equal(t, RuleEffectDISABLE, rules[1].Effect)
+	assert.Nil(t, mixed.UISchema.(*Control).Extra)
+
+	_, err = Parse([]byte(`{"type": "Control", "scope": "#/properties/email", "rules": {"effect": "HIDE"}}`), nil)
+	require.ErrorIs(t, err, ErrInvalidRules)
+
+	_, err = Parse([]byte(`{"type": "Control", "scope": "#/properties/email", "rules": ["HIDE"]}`), nil)
+	require.ErrorIs(t, err, ErrInvalidRules)
+}
+
+// singleRuleElement implements UISchemaElement without GetRules, like external implementations
+type singleRuleElement struct {
+	rule *Rule
+}
+
+func (e *singleRuleElement) GetType() string            { return "Single" }
+func (e *singleRuleElement) GetRule() *Rule             { return e.rule }
+func (e *singleRuleElement) GetOptions() map[string]any { return nil }
+func (e *singleRuleElement) GetI18n() *string           { return nil }
+
+func TestElementRules(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "Control",
+		"scope": "#/properties/email",
+		"rules": [
+			{"effect": "HIDE", "condition": {"type": "LEAF", "scope": "#/properties/anonymous", "expectedValue": true}},
+			{"effect": "DISABLE", "condition": {"type": "LEAF", "scope": "#/properties/locked", "expectedValue": true}}
+		]
+	}`), nil)
+	require.NoError(t, err)
+
+	control, ok := result.UISchema.(*Control)
+	require.True(t, ok, "Expected Control, got %T", result.UISchema)
+
+	replacement := &Rule{Effect: RuleEffectSHOW, Condition: &LeafCondition{Type: "LEAF", Scope: "#/properties/shown", ExpectedValue: true}}
+	control.Rule = replacement
+
+	rules := control.GetRules()
+	require.Len(t, rules, 2)
+	assert.Same(t, replacement, rules[0])
+	assert.Equal(t, RuleEffectDISABLE, rules[1].Effect)
+
+	control.Rule = nil
+	rules = ElementRules(control)
+	require.Len(t, rules, 1)
+	assert.Equal(t, RuleEffectDISABLE, rules[0].Effect)
+
+	rule := &Rule{Effect: RuleEffectHIDE}
+	assert.Equal(t, []*Rule{rule}, ElementRules(&singleRuleElement{rule: rule}))
+	assert.Empty(t, ElementRules(&singleRuleElement{}))
+}
+
+func TestParseSkipInvalidElements(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{"type": "Control", "scope": "#/properties/name"},
+			{"type": "Control", "label": "Broken"},
+			{"type": "Control", "scope": "#/properties/email"}
+		]
+	}`)
+
+	_, err := Parse(uiSchema, nil)
+	require.ErrorIs(t, err, ErrControlMissingScope)
+
+	result, err := ParseWithOptions(uiSchema, nil, ParseOptions{SkipInvalidElements: true})
+	require.NoError(t, err)
+
+	layout, ok := result.UISchema.(*VerticalLayout)
+	require.True(t, ok, "Expected VerticalLayout, got %T", result.UISchema)
+	require.Len(t, layout.Elements, 3)
+
+	var valid []string
+
+	for _, element := range layout.Elements {
+		if control, ok := element.(*Control); ok {
+			valid = append(valid, control.Scope)
+		}
+	}
+
+	assert.Equal(t, []string{"#/properties/name", "#/properties/email"}, valid)
+
+	invalid := result.InvalidElements()
+	require.Len(t, invalid, 1)
+	assert.Same(t, layout.Elements[1], invalid[0])
+	require.ErrorIs(t, invalid[0].Err, ErrControlMissingScope)
+	assert.Equal(t, "Broken", invalid[0].RawData["label"])
+}
+
+func TestParseStrictConditionFields(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "Control",
+		"scope": "#/properties/email",
+		"rule": {
+			"effect": "SHOW",
+			"condition": {
+				"type": "LEAF",
+				"scope": "#/properties/subscribe",
+				"expectedValue": true,
+				"expectdValue": false
+			}
+		}
+	}`)
+
+	_, err := Parse(uiSchema, nil)
+	require.NoError(t, err)
+
+	_, err = ParseWithOptions(uiSchema, nil, ParseOptions{StrictConditionFields: true})
+	require.ErrorIs(t, err, ErrUnknownConditionField)
+	assert.Contains(t, err.Error(), `"expectdValue"`)
+
+	_, err = ParseWithOptions([]byte(`{
+		"type": "Control",
+		"scope": "#/properties/email",
+		"rule": {
+			"effect": "SHOW",
+			"condition": {
+				"type": "AND",
+				"conditions": [
+					{"scope": "#/properties/age", "schema": {"minimum": 18}, "failWhenUndefined": true}
+				]
+			}
+		}
+	}`), nil, ParseOptions{StrictConditionFields: true})
+	require.NoError(t, err)
+}
+
+// rangeCondition is a custom condition type used to test condition parser registration
+type rangeCondition struct {
+	Scope string
+	Min   float64
+	Max   float64
+}
+
+func (r *rangeCondition) GetType() string {
+	return "RANGE"
+}
+
+func parseRangeCondition(data map[string]any) (Condition, error) {
+	scope, _ := data["scope"].(string)
+	minimum, _ := data["min"].(float64)
+	maximum, _ := data["max"].(float64)
+
+	return &rangeCondition{Scope: scope, Min: minimum, Max: maximum}, nil
+}
+
+func TestRegisterConditionParser(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "Control",
+		"scope": "#/properties/discount",
+		"rule": {
+			"effect": "SHOW",
+			"condition": {"type": "RANGE", "scope": "#/properties/age", "min": 18, "max": 65}
+		}
+	}`)
+
+	_, err := Parse(uiSchema, nil)
+	require.ErrorIs(t, err, ErrUnknownConditionType)
+
+	// Per-parse override without touching the registry
+	result, err := ParseWithOptions(uiSchema, nil, ParseOptions{
+		ConditionParsers: map[string]ConditionParser{"RANGE": parseRangeCondition},
+	})
+	require.NoError(t, err)
+
+	condition, ok := result.UISchema.GetRule().Condition.(*rangeCondition)
+	require.True(t, ok, "Expected rangeCondition, got %T", result.UISchema.GetRule().Condition)
+	assert.Equal(t, &rangeCondition{Scope: "#/properties/age", Min: 18, Max: 65}, condition)
+
+	RegisterConditionParser("RANGE", parseRangeCondition)
+	t.Cleanup(func() {
+		conditionParsersMu.Lock()
+		delete(conditionParsers, "RANGE")
+		conditionParsersMu.Unlock()
+	})
+
+	result, err = Parse(uiSchema, nil)
+	require.NoError(t, err)
+
+	_, ok = result.UISchema.GetRule().Condition.(*rangeCondition)
+	assert.True(t, ok, "Expected rangeCondition, got %T", result.UISchema.GetRule().Condition)
+}
+
+func TestParsePreserveRaw(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{"type": "Control", "scope": "#/properties/name", "label": "Name", "options": {"trim": true}}
+		]
+	}`)
+
+	result, err := Parse(uiSchema, nil)
+	require.NoError(t, err)
+
+	layout, ok := result.UISchema.(*VerticalLayout)
+	require.True(t, ok, "Expected VerticalLayout, got %T", result.UISchema)
+
+	control, ok := layout.Elements[0].(*Control)
+	require.True(t, ok, "Expected Control, got %T", layout.Elements[0])
+	assert.Nil(t, control.Raw)
+
+	result, err = ParseWithOptions(uiSchema, nil, ParseOptions{PreserveRaw: true})
+	require.NoError(t, err)
+
+	layout, ok = result.UISchema.(*VerticalLayout)
+	require.True(t, ok, "Expected VerticalLayout, got %T", result.UISchema)
+	assert.Equal(t, "VerticalLayout", layout.Raw["type"])
+
+	control, ok = layout.Elements[0].(*Control)
+	require.True(t, ok, "Expected Control, got %T", layout.Elements[0])
+	assert.Equal(t, map[string]any{
+		"type":    "Control",
+		"scope":   "#/properties/name",
+		"label":   "Name",
+		"options": map[string]any{"trim": true},
+	}, control.Raw)
+}
+
+func TestFuncVisitor(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{"type": "Control", "scope": "#/properties/name"},
+			{
+				"type": "Group",
+				"label": "Address",
+				"elements": [
+					{"type": "Control", "scope": "#/properties/street"},
+					{"type": "Label", "text": "Note"}
+				]
+			}
+		]
+	}`), nil)
+	require.NoError(t, err)
+
+	controls := 0
+	err = Walk(result.UISchema, &FuncVisitor{
+		OnControl: func(*Control) error {
+			controls++
+
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, controls)
+}
+
+func FuzzParse(f *testing.F) {
+	seeds := []string{
+		`{"type": "Control", "scope": "#/properties/name"}`,
+		`{"type": "VerticalLayout", "elements": [{"type": "Control", "scope": "#/properties/name"}]}`,
+		`{"type": "Group", "label": "Address", "elements": [{"type": "Label", "text": "Street"}]}`,
+		`{"type": "Categorization", "elements": [{"type": "Category", "label": "Main", "elements": []}]}`,
+		`{"type": "Control", "scope": "#/properties/email", "rule": {"effect": "SHOW", "condition": {"type": "LEAF", "scope": "#/properties/subscribe", "expectedValue": true}}}`,
+		`{"type": "Control", "scope": "#/properties/age", "rule": {"effect": "HIDE", "condition": {"type": "AND", "conditions": [{"scope": "#/properties/a", "schema": {"const": 1}}]}}}`,
+		`{"type": "Control", "scope": "#/properties/items", "options": {"detail": {"type": "VerticalLayout", "elements": []}}}`,
+		`{"type": "Panel", "elements": [{"type": "Control"}], "rules": [{"effect": "SHOW"}]}`,
+		`{"type": 1}`,
+		`[]`,
+		`null`,
+	}
+
+	for _, seed := range seeds {
+		f.Add([]byte(seed), []byte(`{"type": "object", "properties": {"name": {"type": "string"}}}`))
+	}
+
+	f.Fuzz(func(t *testing.T, uiSchema, schema []byte) {
+		// Any input must produce either an AST or an error, never a panic
+		result, err := Parse(uiSchema, schema)
+		if err == nil {
+			assert.NotNil(t, result)
+		}
+
+		_, _ = ParseWithOptions(uiSchema, schema, ParseOptions{
+			SkipInvalidElements:   true,
+			AllowCustomConditions: true,
+			UseNumber:             true,
+			RejectDuplicateKeys:   true,
+		})
+	})
+}
+
+func TestParseKeyedElements(t *testing.T) {
+	keyed := []byte(`{
+		"type": "VerticalLayout",
+		"elements": {
+			"b-email": {"type": "Control", "scope": "#/properties/email"},
+			"a-name": {"type": "Control", "scope": "#/properties/name"},
+			"c-notes": {"type": "Label", "text": "Notes"}
+		}
+	}`)
+
+	_, err := Parse(keyed, nil)
+	require.ErrorIs(t, err, ErrMissingElements)
+
+	result, err := ParseWithOptions(keyed, nil, ParseOptions{AllowKeyedElements: true})
+	require.NoError(t, err)
+
+	layout, ok := result.UISchema.(*VerticalLayout)
+	require.True(t, ok, "Expected VerticalLayout, got %T", result.UISchema)
+	require.Len(t, layout.Elements, 3)
+
+	name, ok := layout.Elements[0].(*Control)
+	require.True(t, ok, "Expected Control, got %T", layout.Elements[0])
+	assert.Equal(t, "#/properties/name", name.Scope)
+
+	email, ok := layout.Elements[1].(*Control)
+	require.True(t, ok, "Expected Control, got %T", layout.Elements[1])
+	assert.Equal(t, "#/properties/email", email.Scope)
+
+	_, ok = layout.Elements[2].(*Label)
+	assert.True(t, ok, "Expected Label, got %T", layout.Elements[2])
+
+	result, err = ParseWithOptions([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [{"type": "Control", "scope": "#/properties/name"}]
+	}`), nil, ParseOptions{AllowKeyedElements: true})
+	require.NoError(t, err)
+
+	layout, ok = result.UISchema.(*VerticalLayout)
+	require.True(t, ok, "Expected VerticalLayout, got %T", result.UISchema)
+	assert.Len(t, layout.Elements, 1)
+}
+
+func TestWalkPostOrder(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{"type": "Control", "scope": "#/properties/name"},
+			{
+				"type": "HorizontalLayout",
+				"elements": [{"type": "Control", "scope": "#/properties/email"}]
+			}
+		]
+	}`), nil)
+	require.NoError(t, err)
+
+	var order []string
+
+	err = WalkPostOrder(result.UISchema, &FuncVisitor{
+		OnControl: func(c *Control) error {
+			order = append(order, c.Scope)
+
+			return nil
+		},
+		OnVerticalLayout: func(*VerticalLayout) error {
+			order = append(order, "VerticalLayout")
+
+			return nil
+		},
+		OnHorizontalLayout: func(*HorizontalLayout) error {
+			order = append(order, "HorizontalLayout")
+
+			return nil
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"#/properties/name", "#/properties/email", "HorizontalLayout", "VerticalLayout"}, order)
+}
+
+func TestParseEmbeddedSchema(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "Control",
+		"scope": "#/properties/name",
+		"schema": {
+			"type": "object",
+			"properties": {"name": {"type": "string", "title": "Embedded"}}
+		}
+	}`)
+
+	result, err := Parse(uiSchema, nil)
+	require.NoError(t, err)
+
+	control, ok := result.UISchema.(*Control)
+	require.True(t, ok, "Expected Control, got %T", result.UISchema)
+	assert.Equal(t, "Embedded", control.EffectiveLabel(result.Schema))
+
+	result, err = Parse(uiSchema, []byte(`{
+		"type": "object",
+		"properties": {"name": {"type": "string", "title": "Explicit"}}
+	}`))
+	require.NoError(t, err)
+
+	control, ok = result.UISchema.(*Control)
+	require.True(t, ok, "Expected Control, got %T", result.UISchema)
+	assert.Equal(t, "Explicit", control.EffectiveLabel(result.Schema))
+}
+
+func TestWalkChecked(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{"type": "Control", "scope": "#/properties/name"},
+			{
+				"type": "Group",
+				"label": "Address",
+				"elements": [{"type": "Control", "scope": "#/properties/street"}]
+			}
+		]
+	}`), nil)
+	require.NoError(t, err)
+
+	errStreet := errors.New("street is not supported")
+
+	err = WalkChecked(result.UISchema, &FuncVisitor{
+		OnControl: func(c *Control) error {
+			if c.Scope == "#/properties/street" {
+				return errStreet
+			}
+
+			return nil
+		},
+	})
+	require.ErrorIs(t, err, errStreet)
+	assert.EqualError(t, err, "at elements[1].elements[0]: street is not supported")
+}
+
+func TestWalkCheckedDetail(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [{
+			"type": "Control",
+			"scope": "#/properties/items",
+			"options": {
+				"detail": {
+					"type": "VerticalLayout",
+					"elements": [
+						{"type": "Control", "scope": "#/properties/name"},
+						{"type": "Control", "scope": "#/properties/price"}
+					]
+				}
+			}
+		}]
+	}`), nil)
+	require.NoError(t, err)
+
+	errPrice := errors.New("price is not supported")
+
+	err = WalkChecked(result.UISchema, &FuncVisitor{
+		OnControl: func(c *Control) error {
+			if c.Scope == "#/properties/price" {
+				return errPrice
+			}
+
+			return nil
+		},
+	})
+	require.ErrorIs(t, err, errPrice)
+	assert.EqualError(t, err, "at elements[0].detail.elements[1]: price is not supported")
+}
+
+func TestParseExpandShorthandRules(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "Control",
+		"scope": "#/properties/email",
+		"options": {
+			"showOn": {"scope": "#/properties/subscribe", "value": true}
+		}
+	}`)
+
+	result, err := Parse(uiSchema, nil)
+	require.NoError(t, err)
+
+	control, ok := result.UISchema.(*Control)
+	require.True(t, ok, "Expected Control, got %T", result.UISchema)
+	assert.Nil(t, control.Rule)
+	assert.Contains(t, control.Options, "showOn")
+
+	result, err = ParseWithOptions(uiSchema, nil, ParseOptions{ExpandShorthandRules: true})
+	require.NoError(t, err)
+
+	control, ok = result.UISchema.(*Control)
+	require.True(t, ok, "Expected Control, got %T", result.UISchema)
+	assert.Nil(t, control.Options)
+	assert.Equal(t, &Rule{
+		Effect: RuleEffectSHOW,
+		Condition: &LeafCondition{
+			Type:          "LEAF",
+			Scope:         "#/properties/subscribe",
+			ExpectedValue: true,
+		},
+	}, control.Rule)
+
+	_, err = ParseWithOptions([]byte(`{
+		"type": "Control",
+		"scope": "#/properties/email",
+		"options": {"showOn": {"value": true}}
+	}`), nil, ParseOptions{ExpandShorthandRules: true})
+	require.ErrorIs(t, err, ErrInvalidShowOn)
+}
+
+func TestParseListWithDetail(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "ListWithDetail",
+		"scope": "#/properties/users",
+		"options": {
+			"detail": {
+				"type": "VerticalLayout",
+				"elements": [
+					{"type": "Control", "scope": "#/properties/name"},
+					{"type": "Control", "scope": "#/properties/email"}
+				]
+			}
+		}
+	}`)
+
+	result, err := Parse(uiSchema, nil)
+	require.NoError(t, err)
+
+	list, ok := result.UISchema.(*ListWithDetail)
+	require.True(t, ok, "Expected ListWithDetail, got %T", result.UISchema)
+	assert.Equal(t, "ListWithDetail", list.GetType())
+	assert.Equal(t, "#/properties/users", list.Scope)
+
+	detail, ok := list.Detail.(*VerticalLayout)
+	require.True(t, ok, "Expected VerticalLayout detail, got %T", list.Detail)
+	assert.Len(t, detail.Elements, 2)
+
+	var visited []string
+
+	err = Walk(result.UISchema, &FuncVisitor{
+		OnListWithDetail: func(l *ListWithDetail) error {
+			visited = append(visited, l.Scope)
+
+			return nil
+		},
+		OnControl: func(c *Control) error {
+			visited = append(visited, c.Scope)
+
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"#/properties/users", "#/properties/name", "#/properties/email"}, visited)
+}
+
+func TestParseListWithDetailMissingScope(t *testing.T) {
+	_, err := Parse([]byte(`{"type": "ListWithDetail"}`), nil)
+	require.ErrorIs(t, err, ErrListWithDetailMissingScope)
+}
+
+func TestParseUISchemasRegistry(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{"type": "Control", "scope": "#/properties/addresses"}
+		],
+		"uischemas": [
+			{
+				"tester": "addressTester",
+				"uischema": {
+					"type": "HorizontalLayout",
+					"elements": [
+						{"type": "Control", "scope": "#/properties/street"},
+						{"type": "Control", "scope": "#/properties/city"}
+					]
+				}
+			},
+			{
+				"scope": "#/properties/contacts",
+				"uischema": {"type": "Control", "scope": "#/properties/email"}
+			}
+		]
+	}`)
+
+	result, err := Parse(uiSchema, nil)
+	require.NoError(t, err)
+	require.Len(t, result.UISchemas, 2)
+
+	first := result.UISchemas[0]
+	assert.Equal(t, "addressTester", first.Tester)
+	assert.Empty(t, first.Scope)
+
+	layout, ok := first.UISchema.(*HorizontalLayout)
+	require.True(t, ok, "Expected HorizontalLayout, got %T", first.UISchema)
+	assert.Len(t, layout.Elements, 2)
+
+	second := result.UISchemas[1]
+	assert.Empty(t, second.Tester)
+	assert.Equal(t, "#/properties/contacts", second.Scope)
+
+	control, ok := second.UISchema.(*Control)
+	require.True(t, ok, "Expected Control, got %T", second.UISchema)
+	assert.Equal(t, "#/properties/email", control.Scope)
+}
+
+func TestParseUISchemasInvalid(t *testing.T) {
+	_, err := Parse([]byte(`{"type": "VerticalLayout", "elements": [], "uischemas": {}}`), nil)
+	require.ErrorIs(t, err, ErrInvalidUISchemas)
+
+	_, err = Parse([]byte(`{"type": "VerticalLayout", "elements": [], "uischemas": [{"tester": "t"}]}`), nil)
+	require.ErrorIs(t, err, ErrInvalidUISchemas)
+	assert.Contains(t, err.Error(), "entry 0")
+
+	_, err = Parse([]byte(`{"type": "VerticalLayout", "elements": [], "uischemas": [{"uischema": {"type": "Control"}}]}`), nil)
+	require.ErrorIs(t, err, ErrControlMissingScope)
 }
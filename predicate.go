@@ -0,0 +1,88 @@
+package jsonforms
+
+import "strings"
+
+// ElementPredicate selects elements for FilterElements
+type ElementPredicate func(UISchemaElement) bool
+
+// FilterElements returns every element matching pred in document order, including custom elements
+// and control detail layouts
+func FilterElements(root UISchemaElement, pred ElementPredicate) []UISchemaElement {
+	var elements []UISchemaElement
+
+	forEachElement(root, func(element UISchemaElement) {
+		if pred(element) {
+			elements = append(elements, element)
+		}
+	})
+
+	return elements
+}
+
+// HasRulePred matches elements with at least one rule
+func HasRulePred() ElementPredicate {
+	return func(element UISchemaElement) bool {
+		return len(ElementRules(element)) > 0
+	}
+}
+
+// ScopePrefixPred matches controls and lists whose scope is prefix or lies below it, so
+// "#/properties/address" matches "#/properties/address/properties/city" but not "#/properties/addressLine"
+func ScopePrefixPred(prefix string) ElementPredicate {
+	return func(element UISchemaElement) bool {
+		var scope string
+
+		switch e := element.(type) {
+		case *Control:
+			scope = e.Scope
+		case *ListWithDetail:
+			scope = e.Scope
+		default:
+			return false
+		}
+
+		return scope == prefix || strings.HasPrefix(scope, strings.TrimSuffix(prefix, "/")+"/")
+	}
+}
+
+// OptionTruePred matches elements whose options set key to true
+func OptionTruePred(key string) ElementPredicate {
+	return func(element UISchemaElement) bool {
+		value, _ := element.GetOptions()[key].(bool)
+
+		return value
+	}
+}
+
+// And matches elements matching every predicate
+func And(preds ...ElementPredicate) ElementPredicate {
+	return func(element UISchemaElement) bool {
+		for _, pred := range preds {
+			if !pred(element) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// Or matches elements matching at least one predicate
+func Or(preds ...ElementPredicate) ElementPredicate {
+	return func(element UISchemaElement) bool {
+		for _, pred := range preds {
+			if pred(element) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// Not matches elements that do not match pred
+func Not(pred ElementPredicate) ElementPredicate {
+	return func(element UISchemaElement) bool {
+		return !pred(element)
+	}
+}
@@ -0,0 +1,48 @@
+package jsonforms
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterElementsPredicates(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{"type": "Control", "scope": "#/properties/name", "options": {"readonly": true}},
+			{"type": "Control", "scope": "#/properties/address/properties/street"},
+			{
+				"type": "Control",
+				"scope": "#/properties/address/properties/city",
+				"options": {"readonly": true},
+				"rule": {
+					"effect": "HIDE",
+					"condition": {"scope": "#/properties/name", "schema": {"const": ""}}
+				}
+			},
+			{
+				"type": "Control",
+				"scope": "#/properties/addressLine",
+				"rule": {
+					"effect": "HIDE",
+					"condition": {"scope": "#/properties/name", "schema": {"const": ""}}
+				}
+			}
+		]
+	}`), nil)
+	require.NoError(t, err)
+
+	layout, ok := result.UISchema.(*VerticalLayout)
+	require.True(t, ok, "Expected VerticalLayout, got %T", result.UISchema)
+
+	inAddress := ScopePrefixPred("#/properties/address")
+
+	assert.Equal(t, []UISchemaElement{layout.Elements[1], layout.Elements[2]}, FilterElements(result.UISchema, inAddress))
+	assert.Equal(t, []UISchemaElement{layout.Elements[2]}, FilterElements(result.UISchema, And(inAddress, HasRulePred())))
+	assert.Equal(t, []UISchemaElement{layout.Elements[1]}, FilterElements(result.UISchema, And(inAddress, Not(HasRulePred()))))
+
+	readonlyOrRule := FilterElements(result.UISchema, Or(OptionTruePred("readonly"), HasRulePred()))
+	assert.Equal(t, []UISchemaElement{layout.Elements[0], layout.Elements[2], layout.Elements[3]}, readonlyOrRule)
+}
@@ -0,0 +1,511 @@
+package jsonforms
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// directChildren returns an element's children, including a control's or list's detail layout
+func directChildren(element UISchemaElement) []UISchemaElement {
+	switch element.(type) {
+	case *Control, *ListWithDetail:
+		if detail := detailOf(element); detail != nil {
+			return []UISchemaElement{detail}
+		}
+
+		return nil
+	}
+
+	entries := childEntries(element)
+	children := make([]UISchemaElement, 0, len(entries))
+
+	for _, entry := range entries {
+		children = append(children, entry.Element)
+	}
+
+	return children
+}
+
+// detailOf returns the parsed detail layout of a control or list, or nil
+func detailOf(element UISchemaElement) UISchemaElement {
+	switch e := element.(type) {
+	case *Control:
+		return e.Detail
+	case *ListWithDetail:
+		return e.Detail
+	default:
+		return nil
+	}
+}
+
+// EffectiveRules maps each element to the rules affecting it: the rules of its ancestors,
+// ordered from outermost to innermost, followed by its own rules. Elements without any
+// applicable rule are omitted.
+func EffectiveRules(root UISchemaElement) map[UISchemaElement][]*Rule {
+	result := map[UISchemaElement][]*Rule{}
+	collectEffectiveRules(root, nil, result)
+
+	return result
+}
+
+func collectEffectiveRules(element UISchemaElement, inherited []*Rule, result map[UISchemaElement][]*Rule) {
+	if element == nil {
+		return
+	}
+
+	rules := inherited
+	if own := ElementRules(element); len(own) > 0 {
+		rules = append(append([]*Rule{}, inherited...), own...)
+	}
+
+	if len(rules) > 0 {
+		result[element] = rules
+	}
+
+	for _, child := range directChildren(element) {
+		collectEffectiveRules(child, rules, result)
+	}
+}
+
+// DependencyScopes returns the distinct scopes read by the rule's condition tree, including
+// conditions nested in AND/OR, in order of first appearance. Relative scopes are returned as written.
+func (r *Rule) DependencyScopes() []string {
+	var scopes []string
+
+	forEachCondition(r.Condition, func(condition Condition) {
+		if scope, ok := conditionScope(condition); ok && !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	})
+
+	return scopes
+}
+
+// InvalidElements returns the placeholders left by ParseOptions.SkipInvalidElements, in document order
+func (ast *AST) InvalidElements() []*InvalidElement {
+	var invalid []*InvalidElement
+
+	forEachElement(ast.UISchema, func(element UISchemaElement) {
+		if e, ok := element.(*InvalidElement); ok {
+			invalid = append(invalid, e)
+		}
+	})
+
+	return invalid
+}
+
+// CollectConditions returns every rule condition whose GetType() equals typeName, including
+// conditions nested in AND/OR and rules inside control detail layouts
+func CollectConditions(root UISchemaElement, typeName string) []Condition {
+	var conditions []Condition
+
+	forEachElement(root, func(element UISchemaElement) {
+		forEachElementCondition(element, func(condition Condition) {
+			if condition.GetType() == typeName {
+				conditions = append(conditions, condition)
+			}
+		})
+	})
+
+	return conditions
+}
+
+// LeafControls returns every control in document order, descending through all containers,
+// custom elements and control detail layouts. A control's detail controls follow the control itself.
+// Controls are matched by Go type, whatever their Type string.
+func LeafControls(root UISchemaElement) []*Control {
+	var controls []*Control
+
+	forEachElement(root, func(element UISchemaElement) {
+		if control, ok := element.(*Control); ok {
+			controls = append(controls, control)
+		}
+	})
+
+	return controls
+}
+
+// Steps returns the categorization's direct Category children, the pages of a wizard.
+// Nested categorizations are not steps.
+func (c *Categorization) Steps() []*Category {
+	var steps []*Category
+
+	for _, child := range c.Elements {
+		if category, ok := child.(*Category); ok {
+			steps = append(steps, category)
+		}
+	}
+
+	return steps
+}
+
+// StepCount returns the number of direct Category children
+func (c *Categorization) StepCount() int {
+	return len(c.Steps())
+}
+
+// HasRules reports whether root or any descendant, including custom element children and
+// control detail layouts, carries a rule. It stops at the first rule found.
+func HasRules(root UISchemaElement) bool {
+	if root == nil {
+		return false
+	}
+
+	if len(ElementRules(root)) > 0 {
+		return true
+	}
+
+	return slices.ContainsFunc(directChildren(root), HasRules)
+}
+
+// CategoryAt returns the child at index i when it is a Category
+func (c *Categorization) CategoryAt(i int) (*Category, bool) {
+	if i < 0 || i >= len(c.Elements) {
+		return nil, false
+	}
+
+	category, ok := c.Elements[i].(*Category)
+
+	return category, ok
+}
+
+// SubCategorizationAt returns the child at index i when it is a nested Categorization
+func (c *Categorization) SubCategorizationAt(i int) (*Categorization, bool) {
+	if i < 0 || i >= len(c.Elements) {
+		return nil, false
+	}
+
+	categorization, ok := c.Elements[i].(*Categorization)
+
+	return categorization, ok
+}
+
+// CountByType counts the elements of each type across the whole tree, including custom elements
+// and control detail layouts
+func CountByType(root UISchemaElement) map[string]int {
+	counts := map[string]int{}
+
+	forEachElement(root, func(element UISchemaElement) {
+		counts[element.GetType()]++
+	})
+
+	return counts
+}
+
+// LayoutFanout returns the largest and the average number of direct children across all layouts,
+// groups, categorizations and categories, plus custom elements that have children. Both are zero
+// when the tree has no containers.
+func LayoutFanout(root UISchemaElement) (int, float64) {
+	largest, total, containers := 0, 0, 0
+
+	forEachElement(root, func(element UISchemaElement) {
+		if !isContainer(element) {
+			return
+		}
+
+		fanout := len(childEntries(element))
+		largest = max(largest, fanout)
+		total += fanout
+		containers++
+	})
+
+	if containers == 0 {
+		return 0, 0
+	}
+
+	return largest, float64(total) / float64(containers)
+}
+
+// isContainer reports whether an element holds child elements
+func isContainer(element UISchemaElement) bool {
+	switch e := element.(type) {
+	case *VerticalLayout, *HorizontalLayout, *Group, *Categorization, *Category:
+		return true
+	case *CustomElement:
+		return len(e.Elements) > 0
+	default:
+		return false
+	}
+}
+
+// AssignTabOrder numbers every control from 1 in document order. Categories are flattened in
+// step order, so numbering continues across tabs. Detail layouts are not numbered since their
+// controls repeat for each array item.
+func AssignTabOrder(root UISchemaElement) map[*Control]int {
+	order := map[*Control]int{}
+
+	_ = WalkWithPath(root, func(element UISchemaElement, _ string) error {
+		if control, ok := element.(*Control); ok {
+			order[control] = len(order) + 1
+		}
+
+		return nil
+	})
+
+	return order
+}
+
+// ResolveI18nPrefix derives each control's full i18n key by joining, with ".", the i18n prefixes of
+// its enclosing groups and categories and the control's own key. A control without an i18n key
+// uses its property name, e.g. a control on "#/properties/name" inside a group with i18n "person"
+// resolves to "person.name".
+func ResolveI18nPrefix(root UISchemaElement) map[*Control]string {
+	keys := map[*Control]string{}
+	resolveI18nPrefix(root, nil, keys)
+
+	return keys
+}
+
+func resolveI18nPrefix(element UISchemaElement, prefixes []string, keys map[*Control]string) {
+	switch e := element.(type) {
+	case nil:
+		return
+	case *Group, *Category:
+		if key := e.GetI18n(); key != nil && *key != "" {
+			prefixes = append(prefixes[:len(prefixes):len(prefixes)], *key)
+		}
+	case *Control:
+		own := lastScopeSegment(e.Scope)
+		if key := e.GetI18n(); key != nil && *key != "" {
+			own = *key
+		}
+
+		keys[e] = strings.Join(append(prefixes[:len(prefixes):len(prefixes)], own), ".")
+	}
+
+	for _, child := range directChildren(element) {
+		resolveI18nPrefix(child, prefixes, keys)
+	}
+}
+
+// ElementsWithOption returns every element, in document order, whose options contain key with any value
+func ElementsWithOption(root UISchemaElement, key string) []UISchemaElement {
+	var elements []UISchemaElement
+
+	forEachElement(root, func(element UISchemaElement) {
+		if _, ok := element.GetOptions()[key]; ok {
+			elements = append(elements, element)
+		}
+	})
+
+	return elements
+}
+
+// CategorizedControl is a control together with the labels of the categories containing it
+type CategorizedControl struct {
+	Path    string // Category labels joined with " > ", e.g. "Main > Sub Tab 1"
+	Control *Control
+}
+
+// FlattenCategories lists every control inside a categorization in document order, with the
+// label path of its enclosing categories. Labels of nested categorizations are included when set.
+func FlattenCategories(c *Categorization) []CategorizedControl {
+	var result []CategorizedControl
+
+	for _, child := range c.Elements {
+		flattenCategories(child, nil, &result)
+	}
+
+	return result
+}
+
+func flattenCategories(element UISchemaElement, labels []string, result *[]CategorizedControl) {
+	switch e := element.(type) {
+	case *Category:
+		labels = append(labels[:len(labels):len(labels)], e.Label)
+	case *Categorization:
+		if e.Label != nil {
+			labels = append(labels[:len(labels):len(labels)], *e.Label)
+		}
+	case *Control:
+		*result = append(*result, CategorizedControl{Path: strings.Join(labels, " > "), Control: e})
+	}
+
+	for _, child := range childEntries(element) {
+		flattenCategories(child.Element, labels, result)
+	}
+}
+
+// ContainerOf finds the first control bound to scope and returns its immediate parent container.
+// Returns false when no such control exists or the control is the root itself.
+func ContainerOf(root UISchemaElement, scope string) (UISchemaElement, bool) {
+	for _, child := range childEntries(root) {
+		if control, ok := child.Element.(*Control); ok && control.Scope == scope {
+			return root, true
+		}
+
+		if container, ok := ContainerOf(child.Element, scope); ok {
+			return container, true
+		}
+	}
+
+	return nil, false
+}
+
+// GetElementAtPath returns the element at a path produced by WalkWithPath or WalkChecked, such as
+// "elements[1].elements[0]". A "detail" segment steps into a Control's or ListWithDetail's detail
+// layout, e.g. "elements[0].detail.elements[1]". The empty path returns root.
+func GetElementAtPath(root UISchemaElement, path string) (UISchemaElement, bool) {
+	if root == nil {
+		return nil, false
+	}
+
+	if path == "" {
+		return root, true
+	}
+
+	current := root
+
+	for _, segment := range strings.Split(path, ".") {
+		if segment == "detail" {
+			if current = detailOf(current); current == nil {
+				return nil, false
+			}
+
+			continue
+		}
+
+		index, ok := parseElementsSegment(segment)
+		if !ok {
+			return nil, false
+		}
+
+		children := childEntries(current)
+		if index >= len(children) {
+			return nil, false
+		}
+
+		current = children[index].Element
+	}
+
+	return current, true
+}
+
+// parseElementsSegment extracts i from a path segment of the form "elements[i]"
+func parseElementsSegment(segment string) (int, bool) {
+	digits, ok := strings.CutPrefix(segment, "elements[")
+	if !ok {
+		return 0, false
+	}
+
+	digits, ok = strings.CutSuffix(digits, "]")
+	if !ok {
+		return 0, false
+	}
+
+	index, err := strconv.Atoi(digits)
+	if err != nil || index < 0 || strconv.Itoa(index) != digits {
+		return 0, false
+	}
+
+	return index, true
+}
+
+// FindRuleCycles builds a dependency graph from rule condition scopes to the scopes of the controls
+// they affect, including rules inherited from containers, and returns each cycle as a sorted list
+// of scopes. A control whose rule reads its own scope forms a cycle of one.
+func FindRuleCycles(root UISchemaElement) [][]string {
+	graph := map[string][]string{}
+	effective := EffectiveRules(root)
+
+	_ = WalkWithPath(root, func(element UISchemaElement, _ string) error {
+		control, ok := element.(*Control)
+		if !ok {
+			return nil
+		}
+
+		if _, ok := graph[control.Scope]; !ok {
+			graph[control.Scope] = nil
+		}
+
+		for _, rule := range effective[control] {
+			forEachCondition(rule.Condition, func(condition Condition) {
+				if dependency := ResolveConditionScope(control, condition); dependency != "" {
+					graph[dependency] = append(graph[dependency], control.Scope)
+				}
+			})
+		}
+
+		return nil
+	})
+
+	var cycles [][]string
+
+	for _, component := range stronglyConnected(graph) {
+		if len(component) > 1 || slices.Contains(graph[component[0]], component[0]) {
+			sort.Strings(component)
+			cycles = append(cycles, component)
+		}
+	}
+
+	sort.Slice(cycles, func(i, j int) bool {
+		return cycles[i][0] < cycles[j][0]
+	})
+
+	return cycles
+}
+
+// stronglyConnected returns the strongly connected components of a graph using Tarjan's algorithm
+func stronglyConnected(graph map[string][]string) [][]string {
+	index := map[string]int{}
+	lowlink := map[string]int{}
+	onStack := map[string]bool{}
+
+	var (
+		stack      []string
+		components [][]string
+		visit      func(node string)
+	)
+
+	visit = func(node string) {
+		index[node] = len(index)
+		lowlink[node] = index[node]
+		stack = append(stack, node)
+		onStack[node] = true
+
+		for _, next := range graph[node] {
+			if _, seen := index[next]; !seen {
+				visit(next)
+				lowlink[node] = min(lowlink[node], lowlink[next])
+			} else if onStack[next] {
+				lowlink[node] = min(lowlink[node], index[next])
+			}
+		}
+
+		if lowlink[node] != index[node] {
+			return
+		}
+
+		var component []string
+
+		for {
+			last := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[last] = false
+			component = append(component, last)
+
+			if last == node {
+				break
+			}
+		}
+
+		components = append(components, component)
+	}
+
+	nodes := make([]string, 0, len(graph))
+	for node := range graph {
+		nodes = append(nodes, node)
+	}
+
+	sort.Strings(nodes)
+
+	for _, node := range nodes {
+		if _, seen := index[node]; !seen {
+			visit(node)
+		}
+	}
+
+	return components
+}
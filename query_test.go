@@ -0,0 +1,686 @@
+package jsonforms
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEffectiveRules(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{
+				"type": "Group",
+				"label": "Outer",
+				"rule": {
+					"effect": "SHOW",
+					"condition": {"type": "LEAF", "scope": "#/properties/a", "expectedValue": true}
+				},
+				"elements": [
+					{
+						"type": "Group",
+						"label": "Inner",
+						"rule": {
+							"effect": "ENABLE",
+							"condition": {"type": "LEAF", "scope": "#/properties/b", "expectedValue": true}
+						},
+						"elements": [
+							{
+								"type": "Control",
+								"scope": "#/properties/c",
+								"rule": {
+									"effect": "HIDE",
+									"condition": {"type": "LEAF", "scope": "#/properties/d", "expectedValue": true}
+								}
+							}
+						]
+					}
+				]
+			},
+			{
+				"type": "Control",
+				"scope": "#/properties/plain"
+			}
+		]
+	}`)
+
+	result, err := Parse(uiSchema, nil)
+	require.NoError(t, err)
+
+	layout, ok := result.UISchema.(*VerticalLayout)
+	require.True(t, ok, "Expected VerticalLayout, got %T", result.UISchema)
+
+	outer, ok := layout.Elements[0].(*Group)
+	require.True(t, ok, "Expected Group, got %T", layout.Elements[0])
+
+	inner, ok := outer.Elements[0].(*Group)
+	require.True(t, ok, "Expected Group, got %T", outer.Elements[0])
+
+	control, ok := inner.Elements[0].(*Control)
+	require.True(t, ok, "Expected Control, got %T", inner.Elements[0])
+
+	rules := EffectiveRules(result.UISchema)
+
+	assert.Equal(t, []*Rule{outer.Rule, inner.Rule, control.Rule}, rules[control])
+	assert.Equal(t, []*Rule{outer.Rule}, rules[outer])
+	assert.NotContains(t, rules, layout.Elements[1])
+	assert.NotContains(t, rules, result.UISchema)
+}
+
+func TestFlattenCategories(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "Categorization",
+		"elements": [
+			{
+				"type": "Category",
+				"label": "Main",
+				"elements": [
+					{
+						"type": "Control",
+						"scope": "#/properties/mainField"
+					},
+					{
+						"type": "Categorization",
+						"elements": [
+							{
+								"type": "Category",
+								"label": "Sub Tab 1",
+								"elements": [
+									{
+										"type": "Group",
+										"label": "Grouped",
+										"elements": [
+											{
+												"type": "Control",
+												"scope": "#/properties/subField1"
+											}
+										]
+									}
+								]
+							}
+						]
+					}
+				]
+			},
+			{
+				"type": "Categorization",
+				"label": "Nested Tabs",
+				"elements": [
+					{
+						"type": "Category",
+						"label": "Sub Tab 2",
+						"elements": [
+							{
+								"type": "Control",
+								"scope": "#/properties/subField2"
+							}
+						]
+					}
+				]
+			}
+		]
+	}`)
+
+	result, err := Parse(uiSchema, nil)
+	require.NoError(t, err)
+
+	categorization, ok := result.UISchema.(*Categorization)
+	require.True(t, ok, "Expected Categorization, got %T", result.UISchema)
+
+	flattened := FlattenCategories(categorization)
+	require.Len(t, flattened, 3)
+
+	assert.Equal(t, "Main", flattened[0].Path)
+	assert.Equal(t, "#/properties/mainField", flattened[0].Control.Scope)
+	assert.Equal(t, "Main > Sub Tab 1", flattened[1].Path)
+	assert.Equal(t, "#/properties/subField1", flattened[1].Control.Scope)
+	assert.Equal(t, "Nested Tabs > Sub Tab 2", flattened[2].Path)
+	assert.Equal(t, "#/properties/subField2", flattened[2].Control.Scope)
+}
+
+func TestContainerOf(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{
+				"type": "Control",
+				"scope": "#/properties/email"
+			},
+			{
+				"type": "Group",
+				"label": "Personal Info",
+				"elements": [
+					{
+						"type": "Control",
+						"scope": "#/properties/name"
+					}
+				]
+			}
+		]
+	}`)
+
+	result, err := Parse(uiSchema, nil)
+	require.NoError(t, err)
+
+	layout, ok := result.UISchema.(*VerticalLayout)
+	require.True(t, ok, "Expected VerticalLayout, got %T", result.UISchema)
+
+	container, ok := ContainerOf(result.UISchema, "#/properties/name")
+	require.True(t, ok)
+	assert.Same(t, layout.Elements[1], container)
+
+	container, ok = ContainerOf(result.UISchema, "#/properties/email")
+	require.True(t, ok)
+	assert.Same(t, layout, container)
+
+	_, ok = ContainerOf(result.UISchema, "#/properties/missing")
+	assert.False(t, ok)
+}
+
+func TestGetElementAtPath(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{
+				"type": "Control",
+				"scope": "#/properties/email"
+			},
+			{
+				"type": "Group",
+				"label": "Personal Info",
+				"elements": [
+					{
+						"type": "HorizontalLayout",
+						"elements": [
+							{
+								"type": "Control",
+								"scope": "#/properties/firstName"
+							},
+							{
+								"type": "Control",
+								"scope": "#/properties/lastName"
+							}
+						]
+					}
+				]
+			}
+		]
+	}`)
+
+	result, err := Parse(uiSchema, nil)
+	require.NoError(t, err)
+
+	element, ok := GetElementAtPath(result.UISchema, "elements[1].elements[0].elements[1]")
+	require.True(t, ok)
+
+	control, ok := element.(*Control)
+	require.True(t, ok, "Expected Control, got %T", element)
+	assert.Equal(t, "#/properties/lastName", control.Scope)
+
+	err = WalkWithPath(result.UISchema, func(element UISchemaElement, path string) error {
+		found, ok := GetElementAtPath(result.UISchema, path)
+		assert.True(t, ok, path)
+		assert.Same(t, element, found, path)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	_, ok = GetElementAtPath(result.UISchema, "elements[1].elements[3]")
+	assert.False(t, ok)
+
+	_, ok = GetElementAtPath(result.UISchema, "elements[0].elements[0]")
+	assert.False(t, ok)
+
+	_, ok = GetElementAtPath(result.UISchema, "children[0]")
+	assert.False(t, ok)
+}
+
+func TestGetElementAtPathDetail(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{"type": "Control", "scope": "#/properties/name"},
+			{
+				"type": "Control",
+				"scope": "#/properties/contacts",
+				"options": {
+					"detail": {
+						"type": "VerticalLayout",
+						"elements": [
+							{"type": "Control", "scope": "#/properties/email"},
+							{"type": "Control", "scope": "#/properties/phone"}
+						]
+					}
+				}
+			}
+		]
+	}`), nil)
+	require.NoError(t, err)
+
+	errPhone := errors.New("phone is not supported")
+
+	err = WalkChecked(result.UISchema, &FuncVisitor{
+		OnControl: func(c *Control) error {
+			if c.Scope == "#/properties/phone" {
+				return errPhone
+			}
+
+			return nil
+		},
+	})
+
+	var pathErr *PathError
+	require.ErrorAs(t, err, &pathErr)
+	assert.Equal(t, "elements[1].detail.elements[1]", pathErr.Path)
+
+	element, ok := GetElementAtPath(result.UISchema, pathErr.Path)
+	require.True(t, ok)
+
+	control, ok := element.(*Control)
+	require.True(t, ok, "Expected Control, got %T", element)
+	assert.Equal(t, "#/properties/phone", control.Scope)
+
+	_, ok = GetElementAtPath(result.UISchema, "elements[0].detail")
+	assert.False(t, ok)
+}
+
+func TestFindRuleCycles(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{
+				"type": "Control",
+				"scope": "#/properties/a",
+				"rule": {
+					"effect": "SHOW",
+					"condition": {"type": "LEAF", "scope": "#/properties/b", "expectedValue": true}
+				}
+			},
+			{
+				"type": "Control",
+				"scope": "#/properties/b",
+				"rule": {
+					"effect": "SHOW",
+					"condition": {"type": "LEAF", "scope": "#/properties/a", "expectedValue": true}
+				}
+			},
+			{
+				"type": "Control",
+				"scope": "#/properties/c",
+				"rule": {
+					"effect": "SHOW",
+					"condition": {"type": "LEAF", "scope": "#/properties/a", "expectedValue": true}
+				}
+			}
+		]
+	}`)
+
+	result, err := Parse(uiSchema, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]string{{"#/properties/a", "#/properties/b"}}, FindRuleCycles(result.UISchema))
+
+	acyclic := NewVerticalLayout(
+		NewControl("#/properties/a"),
+		NewControl("#/properties/b").WithRule(NewRule(RuleEffectSHOW, NewLeafCondition("#/properties/a", true))),
+	)
+	assert.Empty(t, FindRuleCycles(acyclic))
+}
+
+func TestRuleDependencyScopes(t *testing.T) {
+	rule := NewRule(RuleEffectSHOW, &AndCondition{
+		Type: "AND",
+		Conditions: []Condition{
+			NewLeafCondition("#/properties/subscribe", true),
+			NewSchemaBasedCondition("#/properties/age", map[string]any{"minimum": 18}),
+			&OrCondition{
+				Type: "OR",
+				Conditions: []Condition{
+					NewLeafCondition("#/properties/subscribe", false),
+				},
+			},
+		},
+	})
+
+	assert.Equal(t, []string{"#/properties/subscribe", "#/properties/age"}, rule.DependencyScopes())
+	assert.Empty(t, (&Rule{Effect: RuleEffectHIDE}).DependencyScopes())
+}
+
+func TestLeafControls(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{"type": "Control", "scope": "#/properties/name"},
+			{
+				"type": "Group",
+				"label": "Address",
+				"elements": [
+					{"type": "Control", "scope": "#/properties/street"},
+					{
+						"type": "HorizontalLayout",
+						"elements": [
+							{"type": "Control", "scope": "#/properties/city"},
+							{"type": "Control", "scope": "#/properties/zip"}
+						]
+					}
+				]
+			},
+			{"type": "Label", "text": "Contacts"},
+			{
+				"type": "Control",
+				"scope": "#/properties/contacts",
+				"options": {
+					"detail": {
+						"type": "VerticalLayout",
+						"elements": [{"type": "Control", "scope": "#/properties/phone"}]
+					}
+				}
+			},
+			{
+				"type": "Panel",
+				"elements": [{"type": "Control", "scope": "#/properties/notes"}]
+			}
+		]
+	}`), nil)
+	require.NoError(t, err)
+
+	var scopes []string
+	for _, control := range LeafControls(result.UISchema) {
+		scopes = append(scopes, control.Scope)
+	}
+
+	assert.Equal(t, []string{
+		"#/properties/name",
+		"#/properties/street",
+		"#/properties/city",
+		"#/properties/zip",
+		"#/properties/contacts",
+		"#/properties/phone",
+		"#/properties/notes",
+	}, scopes)
+}
+
+func TestLeafControlsMatchesGoType(t *testing.T) {
+	literal := &Control{Scope: "#/properties/name"}
+	lowercase := &Control{BaseUISchemaElement: BaseUISchemaElement{Type: "control"}, Scope: "#/properties/age"}
+	built := NewControl("#/properties/email")
+
+	root := NewVerticalLayout(literal, lowercase, built)
+
+	assert.Equal(t, []*Control{literal, lowercase, built}, LeafControls(root))
+	assert.Len(t, AssignTabOrder(root), 3)
+}
+
+func TestCategorizationSteps(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "Categorization",
+		"options": {"variant": "stepper"},
+		"elements": [
+			{"type": "Category", "label": "Personal", "elements": []},
+			{
+				"type": "Categorization",
+				"elements": [
+					{"type": "Category", "label": "Nested", "elements": []}
+				]
+			},
+			{"type": "Category", "label": "Review", "elements": []}
+		]
+	}`), nil)
+	require.NoError(t, err)
+
+	categorization, ok := result.UISchema.(*Categorization)
+	require.True(t, ok, "Expected Categorization, got %T", result.UISchema)
+
+	assert.Equal(t, 2, categorization.StepCount())
+
+	steps := categorization.Steps()
+	require.Len(t, steps, 2)
+	assert.Equal(t, "Personal", steps[0].Label)
+	assert.Equal(t, "Review", steps[1].Label)
+}
+
+func TestHasRules(t *testing.T) {
+	deep, err := Parse([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{"type": "Control", "scope": "#/properties/name"},
+			{
+				"type": "Control",
+				"scope": "#/properties/contacts",
+				"options": {
+					"detail": {
+						"type": "Group",
+						"label": "Contact",
+						"elements": [
+							{
+								"type": "Control",
+								"scope": "#/properties/phone",
+								"rule": {
+									"effect": "HIDE",
+									"condition": {"type": "LEAF", "scope": "#/properties/private", "expectedValue": true}
+								}
+							}
+						]
+					}
+				}
+			}
+		]
+	}`), nil)
+	require.NoError(t, err)
+	assert.True(t, HasRules(deep.UISchema))
+
+	static, err := Parse([]byte(`{
+		"type": "HorizontalLayout",
+		"elements": [
+			{"type": "Control", "scope": "#/properties/name"},
+			{"type": "Panel", "elements": [{"type": "Label", "text": "Note"}]}
+		]
+	}`), nil)
+	require.NoError(t, err)
+	assert.False(t, HasRules(static.UISchema))
+	assert.False(t, HasRules(nil))
+}
+
+func TestCategorizationChildAccessors(t *testing.T) {
+	categorization := NewCategorization(
+		NewCategory("Personal"),
+		NewCategorization(NewCategory("Nested")),
+	)
+
+	category, ok := categorization.CategoryAt(0)
+	require.True(t, ok)
+	assert.Equal(t, "Personal", category.Label)
+
+	nested, ok := categorization.SubCategorizationAt(1)
+	require.True(t, ok)
+	assert.Len(t, nested.Elements, 1)
+
+	_, ok = categorization.CategoryAt(1)
+	assert.False(t, ok)
+
+	_, ok = categorization.SubCategorizationAt(0)
+	assert.False(t, ok)
+
+	_, ok = categorization.CategoryAt(2)
+	assert.False(t, ok)
+
+	_, ok = categorization.SubCategorizationAt(-1)
+	assert.False(t, ok)
+}
+
+func TestCollectConditions(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "VerticalLayout",
+		"rule": {
+			"effect": "SHOW",
+			"condition": {"type": "LEAF", "scope": "#/properties/enabled", "expectedValue": true}
+		},
+		"elements": [
+			{
+				"type": "Control",
+				"scope": "#/properties/email",
+				"rule": {
+					"effect": "ENABLE",
+					"condition": {
+						"type": "AND",
+						"conditions": [
+							{"type": "LEAF", "scope": "#/properties/subscribe", "expectedValue": true},
+							{
+								"type": "OR",
+								"conditions": [
+									{"type": "LEAF", "scope": "#/properties/plan", "expectedValue": "pro"},
+									{"scope": "#/properties/age", "schema": {"minimum": 18}}
+								]
+							}
+						]
+					}
+				}
+			}
+		]
+	}`), nil)
+	require.NoError(t, err)
+
+	leaves := CollectConditions(result.UISchema, "LEAF")
+	require.Len(t, leaves, 3)
+
+	var scopes []string
+
+	for _, condition := range leaves {
+		leaf, ok := condition.(*LeafCondition)
+		require.True(t, ok, "Expected LeafCondition, got %T", condition)
+
+		scopes = append(scopes, leaf.Scope)
+	}
+
+	assert.Equal(t, []string{"#/properties/enabled", "#/properties/subscribe", "#/properties/plan"}, scopes)
+	assert.Len(t, CollectConditions(result.UISchema, "SCHEMA_BASED"), 1)
+	assert.Len(t, CollectConditions(result.UISchema, "OR"), 1)
+}
+
+func TestResolveI18nPrefix(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{"type": "Control", "scope": "#/properties/email", "i18n": "contact.email"},
+			{
+				"type": "Group",
+				"label": "Person",
+				"i18n": "person",
+				"elements": [
+					{"type": "Control", "scope": "#/properties/firstName", "i18n": "first"},
+					{"type": "Control", "scope": "#/properties/age"}
+				]
+			}
+		]
+	}`), nil)
+	require.NoError(t, err)
+
+	keys := map[string]string{}
+	for control, key := range ResolveI18nPrefix(result.UISchema) {
+		keys[control.Scope] = key
+	}
+
+	assert.Equal(t, map[string]string{
+		"#/properties/email":     "contact.email",
+		"#/properties/firstName": "person.first",
+		"#/properties/age":       "person.age",
+	}, keys)
+}
+
+func TestElementsWithOption(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{"type": "Control", "scope": "#/properties/id", "options": {"readonly": true}},
+			{"type": "Control", "scope": "#/properties/name", "options": {"readonly": false}},
+			{"type": "Control", "scope": "#/properties/email", "options": {"trim": true}},
+			{"type": "Notice", "text": "Locked", "options": {"readonly": null}}
+		]
+	}`), nil)
+	require.NoError(t, err)
+
+	layout, ok := result.UISchema.(*VerticalLayout)
+	require.True(t, ok, "Expected VerticalLayout, got %T", result.UISchema)
+
+	readonly := ElementsWithOption(result.UISchema, "readonly")
+	require.Len(t, readonly, 3)
+	assert.Same(t, layout.Elements[0], readonly[0])
+	assert.Same(t, layout.Elements[1], readonly[1])
+	assert.IsType(t, &CustomElement{}, readonly[2])
+
+	assert.Empty(t, ElementsWithOption(result.UISchema, "missing"))
+}
+
+func TestLayoutFanout(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{"type": "Control", "scope": "#/properties/a"},
+			{
+				"type": "HorizontalLayout",
+				"elements": [
+					{"type": "Control", "scope": "#/properties/b"},
+					{"type": "Control", "scope": "#/properties/c"},
+					{"type": "Control", "scope": "#/properties/d"},
+					{"type": "Control", "scope": "#/properties/e"}
+				]
+			},
+			{
+				"type": "Group",
+				"label": "Empty",
+				"elements": []
+			}
+		]
+	}`), nil)
+	require.NoError(t, err)
+
+	largest, average := LayoutFanout(result.UISchema)
+	assert.Equal(t, 4, largest)
+	assert.InDelta(t, 7.0/3.0, average, 1e-9)
+
+	largest, average = LayoutFanout(NewControl("#/properties/a"))
+	assert.Equal(t, 0, largest)
+	assert.Zero(t, average)
+}
+
+func TestAssignTabOrder(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "Categorization",
+		"elements": [
+			{
+				"type": "Category",
+				"label": "Personal",
+				"elements": [
+					{"type": "Control", "scope": "#/properties/first"},
+					{"type": "Control", "scope": "#/properties/last"}
+				]
+			},
+			{
+				"type": "Category",
+				"label": "Contact",
+				"elements": [
+					{"type": "Label", "text": "How can we reach you?"},
+					{"type": "Control", "scope": "#/properties/email"}
+				]
+			}
+		]
+	}`), nil)
+	require.NoError(t, err)
+
+	order := AssignTabOrder(result.UISchema)
+
+	indices := map[string]int{}
+	for control, index := range order {
+		indices[control.Scope] = index
+	}
+
+	assert.Equal(t, map[string]int{
+		"#/properties/first": 1,
+		"#/properties/last":  2,
+		"#/properties/email": 3,
+	}, indices)
+}
@@ -0,0 +1,580 @@
+package jsonforms
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Static errors for schema extraction
+var (
+	ErrUnsupportedScope = errors.New("scope is not a chain of properties")
+)
+
+// scopeSegments splits a scope such as "#/properties/name" into unescaped JSON Pointer segments
+func scopeSegments(scope string) ([]string, bool) {
+	if !strings.HasPrefix(scope, "#") {
+		return nil, false
+	}
+
+	pointer := strings.TrimPrefix(scope, "#")
+	if pointer == "" {
+		return nil, true
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, false
+	}
+
+	segments := strings.Split(pointer[1:], "/")
+	for i, segment := range segments {
+		segment = strings.ReplaceAll(segment, "~1", "/")
+		segments[i] = strings.ReplaceAll(segment, "~0", "~")
+	}
+
+	return segments, true
+}
+
+// ResolveScope resolves a scope such as "#/properties/address/properties/street" against a data schema,
+// returning the sub-schema it points to. Numeric segments index into array-valued keywords
+// such as tuple "items".
+func ResolveScope(schema any, scope string) (any, bool) {
+	if schema == nil {
+		return nil, false
+	}
+
+	segments, ok := scopeSegments(scope)
+	if !ok {
+		return nil, false
+	}
+
+	current := schema
+
+	for _, segment := range segments {
+		switch node := current.(type) {
+		case map[string]any:
+			if current, ok = node[segment]; !ok {
+				return nil, false
+			}
+		case []any:
+			// Tuple validation uses an array of item schemas addressed by index
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, false
+			}
+
+			current = node[index]
+		default:
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+// ResolveDetailScope resolves a scope from an array control's detail layout, which is relative to
+// the array's item schema, e.g. "#/properties/street" within the items of "#/properties/addresses"
+func ResolveDetailScope(schema any, arrayScope, detailScope string) (any, bool) {
+	arraySchema, ok := ResolveScope(schema, arrayScope)
+	if !ok {
+		return nil, false
+	}
+
+	node, _ := arraySchema.(map[string]any)
+
+	return ResolveScope(node["items"], detailScope)
+}
+
+// schemaProperty returns the named entry of an object schema's "properties"
+func schemaProperty(schema any, name string) (any, bool) {
+	node, ok := schema.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+
+	properties, ok := node["properties"].(map[string]any)
+	if !ok {
+		return nil, false
+	}
+
+	property, ok := properties[name]
+
+	return property, ok
+}
+
+// schemaRequires reports whether an object schema lists name in its "required" array
+func schemaRequires(schema any, name string) bool {
+	node, ok := schema.(map[string]any)
+	if !ok {
+		return false
+	}
+
+	required, _ := node["required"].([]any)
+	for _, entry := range required {
+		if entry == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// propertyPath converts a scope made only of "properties" hops into its property names,
+// e.g. "#/properties/address/properties/street" becomes ["address", "street"]
+func propertyPath(scope string) ([]string, bool) {
+	segments, ok := scopeSegments(scope)
+	if !ok || len(segments) == 0 || len(segments)%2 != 0 {
+		return nil, false
+	}
+
+	names := make([]string, 0, len(segments)/2)
+
+	for i := 0; i < len(segments); i += 2 {
+		if segments[i] != "properties" {
+			return nil, false
+		}
+
+		names = append(names, segments[i+1])
+	}
+
+	return names, true
+}
+
+// splitPropertyScope splits "#/properties/a/properties/b" into the enclosing object's scope
+// ("#/properties/a") and the property name ("b")
+func splitPropertyScope(scope string) (string, string, bool) {
+	index := strings.LastIndex(scope, "/properties/")
+	if index < 0 {
+		return "", "", false
+	}
+
+	name := scope[index+len("/properties/"):]
+	if name == "" || strings.Contains(name, "/") {
+		return "", "", false
+	}
+
+	name = strings.ReplaceAll(strings.ReplaceAll(name, "~1", "/"), "~0", "~")
+
+	return scope[:index], name, true
+}
+
+// schemaType returns a schema's "type", using the first non-null entry when it is an array
+func schemaType(schema any) (string, bool) {
+	node, ok := schema.(map[string]any)
+	if !ok {
+		return "", false
+	}
+
+	switch t := node["type"].(type) {
+	case string:
+		return t, true
+	case []any:
+		for _, entry := range t {
+			if name, ok := entry.(string); ok && name != "null" {
+				return name, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// scopeRequired reports whether the property a scope points to is listed in its enclosing object's "required"
+func scopeRequired(schema any, scope string) bool {
+	parentScope, name, ok := splitPropertyScope(scope)
+	if !ok {
+		return false
+	}
+
+	parent, ok := ResolveScope(schema, parentScope)
+
+	return ok && schemaRequires(parent, name)
+}
+
+// IsRequired reports whether the control's property is listed in the "required" array
+// of its enclosing object schema
+func (c *Control) IsRequired(schema any) bool {
+	return scopeRequired(schema, c.Scope)
+}
+
+// DataType returns the JSON Schema type of the control's bound property, such as "string" or
+// "array". Nullable types like ["string", "null"] report their first non-null entry.
+func (c *Control) DataType(schema any) (string, bool) {
+	sub, ok := ResolveScope(schema, c.Scope)
+	if !ok {
+		return "", false
+	}
+
+	return schemaType(sub)
+}
+
+// IsArray reports whether the control's bound property has type "array", either alone or as one
+// entry of a type array such as ["array", "null"]
+func (c *Control) IsArray(schema any) bool {
+	sub, ok := ResolveScope(schema, c.Scope)
+	if !ok {
+		return false
+	}
+
+	node, _ := sub.(map[string]any)
+
+	switch t := node["type"].(type) {
+	case string:
+		return t == "array"
+	case []any:
+		return slices.Contains(t, any("array"))
+	default:
+		return false
+	}
+}
+
+// OneOfOption is a single const/title branch of a oneOf enumeration
+type OneOfOption struct {
+	Const any    `json:"const"`
+	Title string `json:"title,omitempty"`
+}
+
+// OneOfOptions returns the const/title branches of the control's bound schema when it uses
+// oneOf instead of enum. Branches without a const are skipped.
+func (c *Control) OneOfOptions(schema any) ([]OneOfOption, bool) {
+	sub, ok := ResolveScope(schema, c.Scope)
+	if !ok {
+		return nil, false
+	}
+
+	node, _ := sub.(map[string]any)
+
+	branches, ok := node["oneOf"].([]any)
+	if !ok {
+		return nil, false
+	}
+
+	var options []OneOfOption
+
+	for _, branch := range branches {
+		branchNode, ok := branch.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		constValue, ok := branchNode["const"]
+		if !ok {
+			continue
+		}
+
+		title, _ := branchNode["title"].(string)
+		options = append(options, OneOfOption{Const: constValue, Title: title})
+	}
+
+	return options, len(options) > 0
+}
+
+// EffectiveLabel returns the label a renderer should show: the explicit string label, else the
+// bound schema's "title", else the scope humanized by HumanizeScope
+func (c *Control) EffectiveLabel(schema any) string {
+	if label, ok := c.Label.(string); ok {
+		return label
+	}
+
+	if sub, ok := ResolveScope(schema, c.Scope); ok {
+		if node, ok := sub.(map[string]any); ok {
+			if title, ok := node["title"].(string); ok {
+				return title
+			}
+		}
+	}
+
+	return HumanizeScope(c.Scope)
+}
+
+// SuggestedWidget picks a renderer widget from the bound schema and the control's options:
+// "dropdown" or "radio" for enums, "checkbox" or "toggle" for booleans, "date", "time" or
+// "datetime" for formatted strings, "textarea" for multi-line strings, "number" or "slider" for
+// numbers, and "array" or "object" for structured properties. Anything else falls back to "text".
+func (c *Control) SuggestedWidget(schema any) string {
+	sub, _ := ResolveScope(schema, c.Scope)
+	node, _ := sub.(map[string]any)
+
+	if _, isEnum := node["enum"]; isEnum {
+		return c.choiceWidget()
+	}
+
+	if _, isOneOf := c.OneOfOptions(schema); isOneOf {
+		return c.choiceWidget()
+	}
+
+	dataType, _ := schemaType(sub)
+
+	switch dataType {
+	case "boolean":
+		if c.IsToggle() {
+			return "toggle"
+		}
+
+		return "checkbox"
+	case "number", "integer":
+		if c.IsSlider() {
+			return "slider"
+		}
+
+		return "number"
+	case "array", "object":
+		return dataType
+	}
+
+	switch format, _ := node["format"].(string); format {
+	case "date", "time":
+		return format
+	case "date-time":
+		return "datetime"
+	}
+
+	if c.IsMultiline() {
+		return "textarea"
+	}
+
+	return "text"
+}
+
+// choiceWidget picks the widget for an enumerated control
+func (c *Control) choiceWidget() string {
+	if format, _ := c.stringOption("format"); format == "radio" {
+		return "radio"
+	}
+
+	return "dropdown"
+}
+
+// EachControlWithSchema calls fn for every control in document order with the sub-schema its scope
+// resolves to, or nil when it does not resolve. Detail layouts are skipped as their scopes are
+// relative to the array item schema. The first error returned by fn stops the walk.
+func EachControlWithSchema(ast *AST, fn func(c *Control, sub any) error) error {
+	return WalkWithPath(ast.UISchema, func(element UISchemaElement, _ string) error {
+		control, ok := element.(*Control)
+		if !ok {
+			return nil
+		}
+
+		sub, _ := ResolveScope(ast.Schema, control.Scope)
+
+		return fn(control, sub)
+	})
+}
+
+// SchemaResolver answers repeated scope lookups against one data schema from a prebuilt index
+type SchemaResolver struct {
+	index map[string]any
+}
+
+// NewSchemaResolver indexes every location in schema by its scope so lookups avoid re-walking it
+func NewSchemaResolver(schema any) *SchemaResolver {
+	resolver := &SchemaResolver{index: map[string]any{}}
+	if schema != nil {
+		resolver.indexNode("#", schema)
+	}
+
+	return resolver
+}
+
+func (r *SchemaResolver) indexNode(scope string, node any) {
+	r.index[scope] = node
+
+	switch n := node.(type) {
+	case map[string]any:
+		for key, child := range n {
+			r.indexNode(scope+"/"+pointerEscaper.Replace(key), child)
+		}
+	case []any:
+		for i, child := range n {
+			r.indexNode(scope+"/"+strconv.Itoa(i), child)
+		}
+	}
+}
+
+// Resolve returns the sub-schema a scope points to, matching ResolveScope
+func (r *SchemaResolver) Resolve(scope string) (any, bool) {
+	sub, ok := r.index[scope]
+
+	return sub, ok
+}
+
+// pointerEscaper escapes JSON Pointer reference tokens
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// RequiredScopes returns the scopes of all properties the data schema marks as required,
+// at every level of nested objects
+func (ast *AST) RequiredScopes() map[string]bool {
+	required := map[string]bool{}
+	collectRequiredScopes(ast.Schema, "#", required)
+
+	return required
+}
+
+func collectRequiredScopes(schema any, scope string, required map[string]bool) {
+	node, ok := schema.(map[string]any)
+	if !ok {
+		return
+	}
+
+	names, _ := node["required"].([]any)
+	for _, name := range names {
+		if name, ok := name.(string); ok {
+			required[scope+"/properties/"+pointerEscaper.Replace(name)] = true
+		}
+	}
+
+	properties, _ := node["properties"].(map[string]any)
+	for name, property := range properties {
+		collectRequiredScopes(property, scope+"/properties/"+pointerEscaper.Replace(name), required)
+	}
+}
+
+// Defaults returns the "default" value of every data schema property that defines one, keyed by
+// its scope, including properties of nested objects
+func (ast *AST) Defaults() map[string]any {
+	defaults := map[string]any{}
+
+	root, _ := ast.Schema.(map[string]any)
+
+	properties, _ := root["properties"].(map[string]any)
+	for name, property := range properties {
+		collectDefaults(property, "#/properties/"+pointerEscaper.Replace(name), defaults)
+	}
+
+	return defaults
+}
+
+func collectDefaults(schema any, scope string, defaults map[string]any) {
+	node, ok := schema.(map[string]any)
+	if !ok {
+		return
+	}
+
+	if value, ok := node["default"]; ok {
+		defaults[scope] = value
+	}
+
+	properties, _ := node["properties"].(map[string]any)
+	for name, property := range properties {
+		collectDefaults(property, scope+"/properties/"+pointerEscaper.Replace(name), defaults)
+	}
+}
+
+// MinimalSchema builds a data schema holding only the properties referenced by control and list scopes and
+// rule conditions. Enclosing objects keep their other keywords, and their "required" arrays are
+// narrowed to the kept properties. Referenced property schemas are shared with ast.Schema rather
+// than copied. Returns nil when the AST has no data schema.
+func MinimalSchema(ast *AST) (any, error) {
+	if ast == nil || ast.Schema == nil {
+		return nil, nil
+	}
+
+	tree := &propertyTree{}
+
+	err := WalkWithPath(ast.UISchema, func(element UISchemaElement, path string) error {
+		var scopes []string
+		if scope := elementScope(element); scope != nil {
+			scopes = append(scopes, *scope)
+		}
+
+		forEachElementCondition(element, func(condition Condition) {
+			if scope := resolveConditionScope(element, condition); scope != "" {
+				scopes = append(scopes, scope)
+			}
+		})
+
+		for _, scope := range scopes {
+			if _, found := ResolveScope(ast.Schema, scope); !found {
+				return &PathError{Path: path, Err: fmt.Errorf("%w: %s", ErrUnresolvableScope, scope)}
+			}
+
+			names, ok := propertyPath(scope)
+			if !ok {
+				return &PathError{Path: path, Err: fmt.Errorf("%w: %s", ErrUnsupportedScope, scope)}
+			}
+
+			tree.add(names)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return minimalSchema(ast.Schema, tree), nil
+}
+
+// propertyTree records which property paths of a schema are referenced
+type propertyTree struct {
+	whole    bool // The whole sub-schema is referenced
+	children map[string]*propertyTree
+}
+
+// add marks a property path as referenced
+func (t *propertyTree) add(names []string) {
+	node := t
+
+	for _, name := range names {
+		if node.whole {
+			return
+		}
+
+		if node.children == nil {
+			node.children = map[string]*propertyTree{}
+		}
+
+		child, ok := node.children[name]
+		if !ok {
+			child = &propertyTree{}
+			node.children[name] = child
+		}
+
+		node = child
+	}
+
+	node.whole = true
+	node.children = nil
+}
+
+// minimalSchema copies an object schema with its properties narrowed to the referenced ones
+func minimalSchema(schema any, tree *propertyTree) any {
+	node, ok := schema.(map[string]any)
+	if !ok || tree.whole {
+		return schema
+	}
+
+	result := make(map[string]any, len(node))
+
+	for key, value := range node {
+		if key != "properties" && key != "required" {
+			result[key] = value
+		}
+	}
+
+	properties, _ := node["properties"].(map[string]any)
+	kept := make(map[string]any, len(tree.children))
+
+	for name, child := range tree.children {
+		kept[name] = minimalSchema(properties[name], child)
+	}
+
+	result["properties"] = kept
+
+	var required []any
+
+	names, _ := node["required"].([]any)
+	for _, name := range names {
+		if _, ok := kept[fmt.Sprint(name)]; ok {
+			required = append(required, name)
+		}
+	}
+
+	if len(required) > 0 {
+		result["required"] = required
+	}
+
+	return result
+}
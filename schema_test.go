@@ -0,0 +1,474 @@
+package jsonforms
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mustSchema decodes a data schema for tests that work with raw schemas
+func mustSchema(t *testing.T, data string) any {
+	t.Helper()
+
+	var schema any
+	require.NoError(t, json.Unmarshal([]byte(data), &schema))
+
+	return schema
+}
+
+func TestControlIsRequired(t *testing.T) {
+	schema := mustSchema(t, `{
+		"type": "object",
+		"required": ["name"],
+		"properties": {
+			"name": {"type": "string"},
+			"nickname": {"type": "string"},
+			"address": {
+				"type": "object",
+				"required": ["street"],
+				"properties": {
+					"street": {"type": "string"},
+					"city": {"type": "string"}
+				}
+			}
+		}
+	}`)
+
+	assert.True(t, (&Control{Scope: "#/properties/name"}).IsRequired(schema))
+	assert.False(t, (&Control{Scope: "#/properties/nickname"}).IsRequired(schema))
+	assert.True(t, (&Control{Scope: "#/properties/address/properties/street"}).IsRequired(schema))
+	assert.False(t, (&Control{Scope: "#/properties/address/properties/city"}).IsRequired(schema))
+}
+
+func TestControlDataType(t *testing.T) {
+	schema := mustSchema(t, `{
+		"type": "object",
+		"properties": {
+			"name": {"type": "string"},
+			"age": {"type": ["null", "integer"]},
+			"tags": {"type": "array", "items": {"type": "string"}}
+		}
+	}`)
+
+	dataType, ok := (&Control{Scope: "#/properties/name"}).DataType(schema)
+	require.True(t, ok)
+	assert.Equal(t, "string", dataType)
+
+	dataType, ok = (&Control{Scope: "#/properties/age"}).DataType(schema)
+	require.True(t, ok)
+	assert.Equal(t, "integer", dataType)
+
+	dataType, ok = (&Control{Scope: "#/properties/tags"}).DataType(schema)
+	require.True(t, ok)
+	assert.Equal(t, "array", dataType)
+
+	_, ok = (&Control{Scope: "#/properties/missing"}).DataType(schema)
+	assert.False(t, ok)
+}
+
+func TestControlOneOfOptions(t *testing.T) {
+	schema := mustSchema(t, `{
+		"type": "object",
+		"properties": {
+			"size": {
+				"oneOf": [
+					{"const": "S", "title": "Small"},
+					{"const": "L", "title": "Large"}
+				]
+			},
+			"color": {"type": "string", "enum": ["red", "green"]}
+		}
+	}`)
+
+	options, ok := (&Control{Scope: "#/properties/size"}).OneOfOptions(schema)
+	require.True(t, ok)
+	assert.Equal(t, []OneOfOption{
+		{Const: "S", Title: "Small"},
+		{Const: "L", Title: "Large"},
+	}, options)
+
+	_, ok = (&Control{Scope: "#/properties/color"}).OneOfOptions(schema)
+	assert.False(t, ok)
+}
+
+func TestResolveScopeItems(t *testing.T) {
+	schema := mustSchema(t, `{
+		"type": "object",
+		"properties": {
+			"coords": {
+				"type": "array",
+				"items": [
+					{"type": "number", "title": "Latitude"},
+					{"type": "number", "title": "Longitude"}
+				]
+			},
+			"tags": {
+				"type": "array",
+				"items": {"type": "string"}
+			}
+		}
+	}`)
+
+	first, ok := ResolveScope(schema, "#/properties/coords/items/0")
+	require.True(t, ok)
+	assert.Equal(t, map[string]any{"type": "number", "title": "Latitude"}, first)
+
+	items, ok := ResolveScope(schema, "#/properties/tags/items")
+	require.True(t, ok)
+	assert.Equal(t, map[string]any{"type": "string"}, items)
+
+	_, ok = ResolveScope(schema, "#/properties/coords/items/2")
+	assert.False(t, ok)
+}
+
+func TestResolveDetailScope(t *testing.T) {
+	schema := mustSchema(t, `{
+		"type": "object",
+		"properties": {
+			"addresses": {
+				"type": "array",
+				"items": {
+					"type": "object",
+					"properties": {
+						"street": {"type": "string"}
+					}
+				}
+			},
+			"name": {"type": "string"}
+		}
+	}`)
+
+	street, ok := ResolveDetailScope(schema, "#/properties/addresses", "#/properties/street")
+	require.True(t, ok)
+	assert.Equal(t, map[string]any{"type": "string"}, street)
+
+	_, ok = ResolveDetailScope(schema, "#/properties/addresses", "#/properties/city")
+	assert.False(t, ok)
+
+	_, ok = ResolveDetailScope(schema, "#/properties/name", "#/properties/street")
+	assert.False(t, ok)
+
+	_, ok = ResolveDetailScope(schema, "#/properties/missing", "#/properties/street")
+	assert.False(t, ok)
+}
+
+func TestControlEffectiveLabel(t *testing.T) {
+	schema := mustSchema(t, `{
+		"type": "object",
+		"properties": {
+			"email": {"type": "string", "title": "E-mail"},
+			"firstName": {"type": "string"}
+		}
+	}`)
+
+	explicit := &Control{Scope: "#/properties/email", Label: "Contact Email"}
+	assert.Equal(t, "Contact Email", explicit.EffectiveLabel(schema))
+
+	titled := &Control{Scope: "#/properties/email"}
+	assert.Equal(t, "E-mail", titled.EffectiveLabel(schema))
+
+	humanized := &Control{Scope: "#/properties/firstName"}
+	assert.Equal(t, "First Name", humanized.EffectiveLabel(schema))
+}
+
+var resolverSchemaJSON = `{
+	"type": "object",
+	"properties": {
+		"name": {"type": "string"},
+		"address": {
+			"type": "object",
+			"properties": {
+				"street": {"type": "string"},
+				"a/b": {"type": "string"}
+			}
+		},
+		"coords": {
+			"type": "array",
+			"items": [{"type": "number"}, {"type": "number"}]
+		}
+	}
+}`
+
+var resolverScopes = []string{
+	"#",
+	"#/properties/name",
+	"#/properties/address/properties/street",
+	"#/properties/address/properties/a~1b",
+	"#/properties/coords/items/1",
+	"#/properties/coords/items/2",
+	"#/properties/missing",
+	"properties/name",
+}
+
+func TestSchemaResolverParity(t *testing.T) {
+	schema := mustSchema(t, resolverSchemaJSON)
+	resolver := NewSchemaResolver(schema)
+
+	for _, scope := range resolverScopes {
+		expected, expectedOK := ResolveScope(schema, scope)
+		actual, actualOK := resolver.Resolve(scope)
+
+		assert.Equal(t, expectedOK, actualOK, scope)
+		assert.Equal(t, expected, actual, scope)
+	}
+
+	_, ok := NewSchemaResolver(nil).Resolve("#")
+	assert.False(t, ok)
+}
+
+func BenchmarkResolveScope(b *testing.B) {
+	var schema any
+	require.NoError(b, json.Unmarshal([]byte(resolverSchemaJSON), &schema))
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		for _, scope := range resolverScopes {
+			ResolveScope(schema, scope)
+		}
+	}
+}
+
+func BenchmarkSchemaResolver(b *testing.B) {
+	var schema any
+	require.NoError(b, json.Unmarshal([]byte(resolverSchemaJSON), &schema))
+
+	resolver := NewSchemaResolver(schema)
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		for _, scope := range resolverScopes {
+			resolver.Resolve(scope)
+		}
+	}
+}
+
+func TestRequiredScopes(t *testing.T) {
+	uiSchema := []byte(`{"type": "Control", "scope": "#/properties/name"}`)
+	schema := []byte(`{
+		"type": "object",
+		"required": ["name"],
+		"properties": {
+			"name": {"type": "string"},
+			"nickname": {"type": "string"},
+			"address": {
+				"type": "object",
+				"required": ["street"],
+				"properties": {
+					"street": {"type": "string"},
+					"city": {"type": "string"}
+				}
+			}
+		}
+	}`)
+
+	result, err := Parse(uiSchema, schema)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]bool{
+		"#/properties/name":                      true,
+		"#/properties/address/properties/street": true,
+	}, result.RequiredScopes())
+}
+
+func TestDefaults(t *testing.T) {
+	ast := &AST{Schema: mustSchema(t, `{
+		"type": "object",
+		"default": {},
+		"properties": {
+			"country": {"type": "string", "default": "NZ"},
+			"name": {"type": "string"},
+			"settings": {
+				"type": "object",
+				"properties": {
+					"theme": {"type": "string", "default": "dark"},
+					"notifications": {"type": "boolean", "default": false}
+				}
+			}
+		}
+	}`)}
+
+	assert.Equal(t, map[string]any{
+		"#/properties/country":                           "NZ",
+		"#/properties/settings/properties/theme":         "dark",
+		"#/properties/settings/properties/notifications": false,
+	}, ast.Defaults())
+
+	assert.Empty(t, (&AST{}).Defaults())
+}
+
+func TestControlSuggestedWidget(t *testing.T) {
+	schema := mustSchema(t, `{
+		"type": "object",
+		"properties": {
+			"subscribe": {"type": "boolean"},
+			"country": {"type": "string", "enum": ["NZ", "AU"]},
+			"plan": {"oneOf": [{"const": "free"}, {"const": "pro"}]},
+			"birthday": {"type": "string", "format": "date"},
+			"notes": {"type": "string"},
+			"age": {"type": "integer"},
+			"tags": {"type": "array", "items": {"type": "string"}}
+		}
+	}`)
+
+	tests := []struct {
+		name    string
+		control *Control
+		want    string
+	}{
+		{name: "boolean", control: NewControl("#/properties/subscribe"), want: "checkbox"},
+		{name: "toggle", control: NewControl("#/properties/subscribe").WithOptions(map[string]any{"toggle": true}), want: "toggle"},
+		{name: "enum", control: NewControl("#/properties/country"), want: "dropdown"},
+		{name: "radio", control: NewControl("#/properties/country").WithOptions(map[string]any{"format": "radio"}), want: "radio"},
+		{name: "oneOf", control: NewControl("#/properties/plan"), want: "dropdown"},
+		{name: "date", control: NewControl("#/properties/birthday"), want: "date"},
+		{name: "textarea", control: NewControl("#/properties/notes").WithOptions(map[string]any{"multi": true}), want: "textarea"},
+		{name: "text", control: NewControl("#/properties/notes"), want: "text"},
+		{name: "number", control: NewControl("#/properties/age"), want: "number"},
+		{name: "array", control: NewControl("#/properties/tags"), want: "array"},
+		{name: "unresolvable", control: NewControl("#/properties/missing"), want: "text"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.control.SuggestedWidget(schema))
+		})
+	}
+}
+
+func TestEachControlWithSchema(t *testing.T) {
+	ast, err := Parse([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{"type": "Control", "scope": "#/properties/name"},
+			{
+				"type": "Group",
+				"label": "Address",
+				"elements": [
+					{"type": "Control", "scope": "#/properties/address/properties/city"}
+				]
+			},
+			{"type": "Control", "scope": "#/properties/missing"}
+		]
+	}`), []byte(`{
+		"type": "object",
+		"properties": {
+			"name": {"type": "string", "minLength": 1},
+			"address": {
+				"type": "object",
+				"properties": {
+					"city": {"type": "string", "title": "City"}
+				}
+			}
+		}
+	}`))
+	require.NoError(t, err)
+
+	fragments := map[string]any{}
+	err = EachControlWithSchema(ast, func(c *Control, sub any) error {
+		fragments[c.Scope] = sub
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"#/properties/name":                    map[string]any{"type": "string", "minLength": float64(1)},
+		"#/properties/address/properties/city": map[string]any{"type": "string", "title": "City"},
+		"#/properties/missing":                 nil,
+	}, fragments)
+
+	stop := errors.New("stop")
+	calls := 0
+	err = EachControlWithSchema(ast, func(*Control, any) error {
+		calls++
+
+		return stop
+	})
+	require.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls)
+}
+
+func TestMinimalSchema(t *testing.T) {
+	ast, err := Parse([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{"type": "Control", "scope": "#/properties/name"},
+			{
+				"type": "Control",
+				"scope": "#/properties/address/properties/city",
+				"rule": {
+					"effect": "SHOW",
+					"condition": {"scope": "#/properties/address/properties/country", "schema": {"const": "NZ"}}
+				}
+			}
+		]
+	}`), []byte(`{
+		"type": "object",
+		"title": "Person",
+		"properties": {
+			"name": {"type": "string"},
+			"age": {"type": "integer"},
+			"address": {
+				"type": "object",
+				"properties": {
+					"city": {"type": "string"},
+					"country": {"type": "string"},
+					"postcode": {"type": "string"}
+				},
+				"required": ["postcode", "city"]
+			},
+			"notes": {"type": "string"}
+		},
+		"required": ["name", "age"]
+	}`))
+	require.NoError(t, err)
+
+	minimal, err := MinimalSchema(ast)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"type":  "object",
+		"title": "Person",
+		"properties": map[string]any{
+			"name": map[string]any{"type": "string"},
+			"address": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"city":    map[string]any{"type": "string"},
+					"country": map[string]any{"type": "string"},
+				},
+				"required": []any{"city"},
+			},
+		},
+		"required": []any{"name"},
+	}, minimal)
+
+	ast.UISchema = NewControl("#/properties/unknown")
+	_, err = MinimalSchema(ast)
+	require.ErrorIs(t, err, ErrUnresolvableScope)
+
+	minimal, err = MinimalSchema(&AST{UISchema: ast.UISchema})
+	require.NoError(t, err)
+	assert.Nil(t, minimal)
+}
+
+func TestControlIsArray(t *testing.T) {
+	schema := mustSchema(t, `{
+		"type": "object",
+		"properties": {
+			"tags": {"type": "array", "items": {"type": "string"}},
+			"aliases": {"type": ["null", "array"]},
+			"name": {"type": "string"}
+		}
+	}`)
+
+	assert.True(t, NewControl("#/properties/tags").IsArray(schema))
+	assert.True(t, NewControl("#/properties/aliases").IsArray(schema))
+	assert.False(t, NewControl("#/properties/name").IsArray(schema))
+	assert.False(t, NewControl("#/properties/missing").IsArray(schema))
+}
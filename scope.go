@@ -0,0 +1,202 @@
+package jsonforms
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Static errors for scope handling
+var (
+	ErrInvalidScope = errors.New("invalid scope")
+)
+
+// isRelativeScope reports whether a condition scope is relative rather than rooted at "#"
+func isRelativeScope(scope string) bool {
+	return !strings.HasPrefix(scope, "#")
+}
+
+// ResolveConditionScope returns the absolute scope a leaf or schema-based condition reads.
+// Absolute scopes are returned unchanged. Relative scopes such as "properties/street" are
+// appended to the control's scope; "." segments are ignored and ".." removes the preceding segment.
+// A nil control resolves relative scopes against the root "#". Returns "" for conditions without a scope.
+func ResolveConditionScope(control *Control, cond Condition) string {
+	if control == nil {
+		return resolveConditionScope(nil, cond)
+	}
+
+	return resolveConditionScope(control, cond)
+}
+
+// elementScope returns a pointer to the scope of a control or list, or nil for other elements
+func elementScope(element UISchemaElement) *string {
+	switch e := element.(type) {
+	case *Control:
+		return &e.Scope
+	case *ListWithDetail:
+		return &e.Scope
+	default:
+		return nil
+	}
+}
+
+// resolveConditionScope resolves a condition scope like ResolveConditionScope, relative to the
+// scope of the owning control or list, or to "#" for any other element
+func resolveConditionScope(element UISchemaElement, cond Condition) string {
+	scope, ok := conditionScope(cond)
+	if !ok {
+		return ""
+	}
+
+	if !isRelativeScope(scope) {
+		return scope
+	}
+
+	base := "#"
+	if owner := elementScope(element); owner != nil {
+		base = *owner
+	}
+
+	segments := strings.Split(strings.TrimSuffix(base, "/"), "/")
+
+	for _, segment := range strings.Split(scope, "/") {
+		switch segment {
+		case "", ".":
+		case "..":
+			if len(segments) > 1 {
+				segments = segments[:len(segments)-1]
+			}
+		default:
+			segments = append(segments, segment)
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// CanonicalizeScope normalizes a scope by dropping empty segments, so "#/properties//b/"
+// becomes "#/properties/b". Scopes must start with "#" followed by "/" or nothing.
+func CanonicalizeScope(scope string) (string, error) {
+	pointer, ok := strings.CutPrefix(scope, "#")
+	if !ok || (pointer != "" && !strings.HasPrefix(pointer, "/")) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidScope, scope)
+	}
+
+	segments := []string{"#"}
+
+	for _, segment := range strings.Split(pointer, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	return strings.Join(segments, "/"), nil
+}
+
+// NormalizeAllScopes canonicalizes every control and list scope and absolute condition scope in place.
+// It stops at the first invalid scope, returning a PathError locating it.
+func NormalizeAllScopes(root UISchemaElement) error {
+	return WalkWithPath(root, func(element UISchemaElement, path string) error {
+		if scope := elementScope(element); scope != nil {
+			if err := canonicalizeInPlace(scope); err != nil {
+				return &PathError{Path: path, Err: err}
+			}
+		}
+
+		var err error
+
+		forEachElementCondition(element, func(condition Condition) {
+			switch c := condition.(type) {
+			case *LeafCondition:
+				if !c.Relative && err == nil {
+					err = canonicalizeInPlace(&c.Scope)
+				}
+			case *SchemaBasedCondition:
+				if !c.Relative && err == nil {
+					err = canonicalizeInPlace(&c.Scope)
+				}
+			}
+		})
+
+		if err != nil {
+			return &PathError{Path: path, Err: err}
+		}
+
+		return nil
+	})
+}
+
+// canonicalizeInPlace replaces a scope with its canonical form
+func canonicalizeInPlace(scope *string) error {
+	canonical, err := CanonicalizeScope(*scope)
+	if err != nil {
+		return err
+	}
+
+	*scope = canonical
+
+	return nil
+}
+
+// lastScopeSegment returns the final unescaped segment of a scope
+func lastScopeSegment(scope string) string {
+	segments, ok := scopeSegments(scope)
+	if !ok || len(segments) == 0 {
+		return ""
+	}
+
+	return segments[len(segments)-1]
+}
+
+// HumanizeScope turns the last segment of a scope into a title, e.g. "#/properties/firstName" becomes
+// "First Name". camelCase, snake_case and kebab-case are split into words, and acronyms stay intact,
+// so "homepageURL" becomes "Homepage URL".
+func HumanizeScope(scope string) string {
+	return humanizeName(lastScopeSegment(scope))
+}
+
+// humanizeName splits a property name into title-cased words
+func humanizeName(name string) string {
+	var words []string
+
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool {
+		return r == '_' || r == '-' || unicode.IsSpace(r)
+	}) {
+		words = append(words, splitCamelCase(part)...)
+	}
+
+	for i, word := range words {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		words[i] = string(runes)
+	}
+
+	return strings.Join(words, " ")
+}
+
+// splitCamelCase splits before each upper-case letter that starts a new word. A run of capitals is
+// kept together as an acronym, ending before a capital followed by a lower-case letter ("URLPath").
+func splitCamelCase(word string) []string {
+	runes := []rune(word)
+
+	var words []string
+
+	start := 0
+
+	for i := 1; i < len(runes); i++ {
+		if !unicode.IsUpper(runes[i]) {
+			continue
+		}
+
+		previousLower := !unicode.IsUpper(runes[i-1])
+		endsAcronym := i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1])
+
+		if previousLower || endsAcronym {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+
+	return append(words, string(runes[start:]))
+}
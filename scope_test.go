@@ -0,0 +1,146 @@
+package jsonforms
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveConditionScopeAbsolute(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "Control",
+		"scope": "#/properties/address",
+		"rule": {
+			"effect": "SHOW",
+			"condition": {
+				"type": "LEAF",
+				"scope": "#/properties/hasAddress",
+				"expectedValue": true
+			}
+		}
+	}`)
+
+	result, err := Parse(uiSchema, nil)
+	require.NoError(t, err)
+
+	control, ok := result.UISchema.(*Control)
+	require.True(t, ok, "Expected Control, got %T", result.UISchema)
+
+	condition, ok := control.Rule.Condition.(*LeafCondition)
+	require.True(t, ok, "Expected LeafCondition, got %T", control.Rule.Condition)
+
+	assert.False(t, condition.Relative)
+	assert.Equal(t, "#/properties/hasAddress", ResolveConditionScope(control, condition))
+}
+
+func TestResolveConditionScopeRelative(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "Control",
+		"scope": "#/properties/address",
+		"rule": {
+			"effect": "ENABLE",
+			"condition": {
+				"scope": "properties/country",
+				"schema": {"const": "US"}
+			}
+		}
+	}`)
+
+	result, err := Parse(uiSchema, nil)
+	require.NoError(t, err)
+
+	control, ok := result.UISchema.(*Control)
+	require.True(t, ok, "Expected Control, got %T", result.UISchema)
+
+	condition, ok := control.Rule.Condition.(*SchemaBasedCondition)
+	require.True(t, ok, "Expected SchemaBasedCondition, got %T", control.Rule.Condition)
+
+	assert.True(t, condition.Relative)
+	assert.Equal(t, "#/properties/address/properties/country", ResolveConditionScope(control, condition))
+
+	sibling := &LeafCondition{Type: "LEAF", Scope: "../../properties/region", Relative: true}
+	assert.Equal(t, "#/properties/region", ResolveConditionScope(control, sibling))
+}
+
+func TestCanonicalizeScope(t *testing.T) {
+	canonical, err := CanonicalizeScope("#/properties//b/")
+	require.NoError(t, err)
+	assert.Equal(t, "#/properties/b", canonical)
+
+	canonical, err = CanonicalizeScope("#/properties/a/properties/b")
+	require.NoError(t, err)
+	assert.Equal(t, "#/properties/a/properties/b", canonical)
+
+	_, err = CanonicalizeScope("properties/a")
+	require.ErrorIs(t, err, ErrInvalidScope)
+}
+
+func TestNormalizeAllScopes(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{
+				"type": "Control",
+				"scope": "#/properties//name",
+				"rule": {
+					"effect": "SHOW",
+					"condition": {
+						"type": "LEAF",
+						"scope": "#//properties/subscribe/",
+						"expectedValue": true
+					}
+				}
+			}
+		]
+	}`)
+
+	result, err := Parse(uiSchema, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, NormalizeAllScopes(result.UISchema))
+
+	layout, ok := result.UISchema.(*VerticalLayout)
+	require.True(t, ok, "Expected VerticalLayout, got %T", result.UISchema)
+
+	control, ok := layout.Elements[0].(*Control)
+	require.True(t, ok, "Expected Control, got %T", layout.Elements[0])
+
+	assert.Equal(t, "#/properties/name", control.Scope)
+	assert.Equal(t, "#/properties/subscribe", control.Rule.Condition.(*LeafCondition).Scope)
+
+	invalid := &VerticalLayout{Elements: []UISchemaElement{&Control{Scope: "name"}}}
+
+	err = NormalizeAllScopes(invalid)
+	require.ErrorIs(t, err, ErrInvalidScope)
+	assert.Contains(t, err.Error(), "at elements[0]")
+}
+
+func TestHumanizeScope(t *testing.T) {
+	tests := []struct {
+		scope string
+		want  string
+	}{
+		{scope: "#/properties/firstName", want: "First Name"},
+		{scope: "#/properties/user_id", want: "User Id"},
+		{scope: "#/properties/home-address", want: "Home Address"},
+		{scope: "#/properties/x", want: "X"},
+		{scope: "#/properties/homepageURL", want: "Homepage URL"},
+		{scope: "#/properties/URLPath", want: "URL Path"},
+		{scope: "#/properties/address/properties/postal_code", want: "Postal Code"},
+		{scope: "#", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scope, func(t *testing.T) {
+			assert.Equal(t, tt.want, HumanizeScope(tt.scope))
+		})
+	}
+}
+
+func TestNormalizeAllScopesListWithDetail(t *testing.T) {
+	list := &ListWithDetail{BaseUISchemaElement: BaseUISchemaElement{Type: "ListWithDetail"}, Scope: "#/properties//users/"}
+
+	require.NoError(t, NormalizeAllScopes(list))
+	assert.Equal(t, "#/properties/users", list.Scope)
+}
@@ -0,0 +1,268 @@
+package jsonforms
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// StreamHandler receives UI schema elements from ParseStream as they are parsed.
+// Paths use the same "elements[i]" format as WalkWithPath. Returning an error stops the parse.
+type StreamHandler interface {
+	OnControl(path string, control *Control) error
+	OnLabel(path string, label *Label) error
+	OnCustomElement(path string, custom *CustomElement) error
+	OnListWithDetail(path string, list *ListWithDetail) error
+	OnLayoutStart(path string, layout UISchemaElement) error
+	OnLayoutEnd(path string, layout UISchemaElement) error
+}
+
+// BaseStreamHandler provides no-op implementations of all StreamHandler methods
+type BaseStreamHandler struct{}
+
+func (h *BaseStreamHandler) OnControl(string, *Control) error               { return nil }
+func (h *BaseStreamHandler) OnLabel(string, *Label) error                   { return nil }
+func (h *BaseStreamHandler) OnCustomElement(string, *CustomElement) error   { return nil }
+func (h *BaseStreamHandler) OnListWithDetail(string, *ListWithDetail) error { return nil }
+func (h *BaseStreamHandler) OnLayoutStart(string, UISchemaElement) error    { return nil }
+func (h *BaseStreamHandler) OnLayoutEnd(string, UISchemaElement) error      { return nil }
+
+// streamContainers are the element types whose children are streamed between OnLayoutStart and OnLayoutEnd
+var streamContainers = map[string]bool{
+	"VerticalLayout":   true,
+	"HorizontalLayout": true,
+	"Group":            true,
+	"Categorization":   true,
+	"Category":         true,
+}
+
+// ParseStream parses a UI schema with ParseStreamReader
+func ParseStream(data []byte, handler StreamHandler) error {
+	return ParseStreamReader(bytes.NewReader(data), handler)
+}
+
+// ParseStreamReader parses a UI schema from a JSON token stream, handing each element to handler
+// instead of building the full tree. Layouts are delivered without their children, which follow
+// between OnLayoutStart and OnLayoutEnd as they are read, so only the keys of the elements being
+// read are held in memory. This needs a layout's "type" and required keys to precede its
+// "elements" array, as is conventional; otherwise that array is buffered as raw JSON until the
+// layout is complete. Keys after "elements" are reflected only in the layout passed to OnLayoutEnd.
+// Custom elements, lists and control detail layouts are delivered whole.
+// Parse errors are returned as a PathError locating the failing element.
+func ParseStreamReader(r io.Reader, handler StreamHandler) error {
+	s := &streamer{parser: &parser{}, decoder: json.NewDecoder(r), handler: handler}
+
+	if err := s.expectDelim('{'); err != nil {
+		return err
+	}
+
+	if err := s.streamObject("", ""); err != nil {
+		return err
+	}
+
+	if _, err := s.decoder.Token(); !errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid JSON: %w", ErrTrailingData)
+	}
+
+	return nil
+}
+
+// streamer reads UI schema elements from one JSON token stream
+type streamer struct {
+	parser  *parser
+	decoder *json.Decoder
+	handler StreamHandler
+}
+
+// expectDelim reads the next token, failing unless it is the given delimiter
+func (s *streamer) expectDelim(delim json.Delim) error {
+	token, err := s.decoder.Token()
+	if err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	if token != delim {
+		return fmt.Errorf("invalid JSON: %w: expected %q, got %v", ErrUnexpectedToken, delim, token)
+	}
+
+	return nil
+}
+
+// streamObject reads the element object whose opening brace was just consumed and reports it.
+// Children of a Categorization that are not categories are skipped, matching Parse.
+func (s *streamer) streamObject(path, parentType string) error {
+	data := map[string]any{}
+
+	var (
+		layout      UISchemaElement
+		rawChildren json.RawMessage
+		keysAfter   bool
+	)
+
+	for s.decoder.More() {
+		token, err := s.decoder.Token()
+		if err != nil {
+			return fmt.Errorf("invalid JSON: %w", err)
+		}
+
+		key, _ := token.(string)
+		elementType, _ := data["type"].(string)
+
+		if key == "elements" && layout == nil && streamContainers[elementType] && categoryAllowed(parentType, elementType) {
+			// Start the layout now unless keys it needs follow "elements"
+			data["elements"] = []any{}
+
+			if start, err := s.parser.parseUISchemaElement(data); err == nil {
+				layout = start
+
+				if err := s.handler.OnLayoutStart(path, layout); err != nil {
+					return err
+				}
+
+				if err := s.streamChildren(path, elementType); err != nil {
+					return err
+				}
+
+				continue
+			}
+		}
+
+		if key == "elements" {
+			if err := s.decoder.Decode(&rawChildren); err != nil {
+				return fmt.Errorf("invalid JSON: %w", err)
+			}
+
+			continue
+		}
+
+		var value any
+		if err := s.decoder.Decode(&value); err != nil {
+			return fmt.Errorf("invalid JSON: %w", err)
+		}
+
+		data[key] = value
+		keysAfter = layout != nil
+	}
+
+	if err := s.expectDelim('}'); err != nil {
+		return err
+	}
+
+	elementType, _ := data["type"].(string)
+	if !categoryAllowed(parentType, elementType) {
+		return nil
+	}
+
+	if layout != nil {
+		return s.endStreamedLayout(path, layout, data, keysAfter)
+	}
+
+	if streamContainers[elementType] && rawChildren != nil {
+		return s.streamBuffered(path, elementType, data, rawChildren)
+	}
+
+	if rawChildren != nil {
+		var children any
+		if err := json.Unmarshal(rawChildren, &children); err != nil {
+			return fmt.Errorf("invalid JSON: %w", err)
+		}
+
+		data["elements"] = children
+	}
+
+	element, err := s.parser.parseUISchemaElement(data)
+	if err != nil {
+		return &PathError{Path: path, Err: err}
+	}
+
+	switch e := element.(type) {
+	case *Control:
+		return s.handler.OnControl(path, e)
+	case *Label:
+		return s.handler.OnLabel(path, e)
+	case *CustomElement:
+		return s.handler.OnCustomElement(path, e)
+	case *ListWithDetail:
+		return s.handler.OnListWithDetail(path, e)
+	default:
+		return nil
+	}
+}
+
+// endStreamedLayout reports the end of a layout whose children were streamed, re-parsing it
+// when keys followed its "elements" array
+func (s *streamer) endStreamedLayout(path string, layout UISchemaElement, data map[string]any, keysAfter bool) error {
+	if keysAfter {
+		complete, err := s.parser.parseUISchemaElement(data)
+		if err != nil {
+			return &PathError{Path: path, Err: err}
+		}
+
+		layout = complete
+	}
+
+	return s.handler.OnLayoutEnd(path, layout)
+}
+
+// streamBuffered reports a layout whose "elements" array had to be buffered, then streams the
+// buffered children
+func (s *streamer) streamBuffered(path, elementType string, data map[string]any, rawChildren json.RawMessage) error {
+	data["elements"] = []any{}
+
+	layout, err := s.parser.parseUISchemaElement(data)
+	if err != nil {
+		return &PathError{Path: path, Err: err}
+	}
+
+	if err := s.handler.OnLayoutStart(path, layout); err != nil {
+		return err
+	}
+
+	buffered := &streamer{parser: s.parser, decoder: json.NewDecoder(bytes.NewReader(rawChildren)), handler: s.handler}
+	if err := buffered.streamChildren(path, elementType); err != nil {
+		return err
+	}
+
+	return s.handler.OnLayoutEnd(path, layout)
+}
+
+// streamChildren reads an "elements" array, streaming each child element
+func (s *streamer) streamChildren(path, parentType string) error {
+	token, err := s.decoder.Token()
+	if err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	if token != json.Delim('[') {
+		return &PathError{Path: path, Err: ErrMissingElements}
+	}
+
+	for i := 0; s.decoder.More(); i++ {
+		token, err := s.decoder.Token()
+		if err != nil {
+			return fmt.Errorf("invalid JSON: %w", err)
+		}
+
+		if token != json.Delim('{') {
+			return &PathError{Path: path, Err: fmt.Errorf("element %d: %w", i, ErrElementNotObject)}
+		}
+
+		if err := s.streamObject(childPath(path, fmt.Sprintf("elements[%d]", i)), parentType); err != nil {
+			return err
+		}
+	}
+
+	return s.expectDelim(']')
+}
+
+// categoryAllowed reports whether an element of elementType may appear under parentType.
+// Categorizations only hold categories, and Parse drops their other typed children.
+func categoryAllowed(parentType, elementType string) bool {
+	if parentType != "Categorization" || elementType == "" {
+		return true
+	}
+
+	return elementType == "Category" || elementType == "Categorization"
+}
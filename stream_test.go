@@ -0,0 +1,216 @@
+package jsonforms
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingStreamHandler records controls and layout nesting seen during ParseStream
+type countingStreamHandler struct {
+	BaseStreamHandler
+	controls []string
+	events   []string
+}
+
+func (h *countingStreamHandler) OnControl(path string, control *Control) error {
+	h.controls = append(h.controls, control.Scope)
+	h.events = append(h.events, "control "+path)
+
+	return nil
+}
+
+func (h *countingStreamHandler) OnLayoutStart(path string, layout UISchemaElement) error {
+	h.events = append(h.events, "start "+layout.GetType()+" "+path)
+
+	return nil
+}
+
+func (h *countingStreamHandler) OnLayoutEnd(path string, layout UISchemaElement) error {
+	h.events = append(h.events, "end "+layout.GetType()+" "+path)
+
+	return nil
+}
+
+func TestParseStream(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{"type": "Control", "scope": "#/properties/name"},
+			{
+				"type": "Group",
+				"label": "Address",
+				"elements": [
+					{"type": "Control", "scope": "#/properties/street"},
+					{
+						"type": "HorizontalLayout",
+						"elements": [
+							{"type": "Control", "scope": "#/properties/city"},
+							{"type": "Control", "scope": "#/properties/zip"}
+						]
+					}
+				]
+			},
+			{"type": "Label", "text": "Done"}
+		]
+	}`)
+
+	handler := &countingStreamHandler{}
+	require.NoError(t, ParseStream(uiSchema, handler))
+
+	assert.Equal(t, []string{"#/properties/name", "#/properties/street", "#/properties/city", "#/properties/zip"}, handler.controls)
+	assert.Equal(t, []string{
+		"start VerticalLayout ",
+		"control elements[0]",
+		"start Group elements[1]",
+		"control elements[1].elements[0]",
+		"start HorizontalLayout elements[1].elements[1]",
+		"control elements[1].elements[1].elements[0]",
+		"control elements[1].elements[1].elements[1]",
+		"end HorizontalLayout elements[1].elements[1]",
+		"end Group elements[1]",
+		"end VerticalLayout ",
+	}, handler.events)
+}
+
+func TestParseStreamError(t *testing.T) {
+	err := ParseStream([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{"type": "Control", "scope": "#/properties/name"},
+			{"type": "Group", "elements": [{"type": "Control"}]}
+		]
+	}`), &BaseStreamHandler{})
+	require.Error(t, err)
+
+	var pathErr *PathError
+	require.ErrorAs(t, err, &pathErr)
+	assert.Equal(t, "elements[1]", pathErr.Path)
+	assert.ErrorIs(t, err, ErrGroupMissingLabel)
+
+	err = ParseStream([]byte(`[{"type": "Control", "scope": "#/properties/name"}]`), &BaseStreamHandler{})
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+}
+
+func TestParseStreamKeyOrder(t *testing.T) {
+	uiSchema := []byte(`{
+		"elements": [
+			{"type": "Control", "scope": "#/properties/name"},
+			{
+				"type": "Group",
+				"elements": [{"type": "Control", "scope": "#/properties/street"}],
+				"label": "Address"
+			}
+		],
+		"type": "VerticalLayout"
+	}`)
+
+	handler := &countingStreamHandler{}
+	require.NoError(t, ParseStream(uiSchema, handler))
+
+	assert.Equal(t, []string{
+		"start VerticalLayout ",
+		"control elements[0]",
+		"start Group elements[1]",
+		"control elements[1].elements[0]",
+		"end Group elements[1]",
+		"end VerticalLayout ",
+	}, handler.events)
+}
+
+// ruleEndHandler records whether the layout passed to OnLayoutEnd has a rule
+type ruleEndHandler struct {
+	BaseStreamHandler
+	startRule, endRule bool
+}
+
+func (h *ruleEndHandler) OnLayoutStart(_ string, layout UISchemaElement) error {
+	h.startRule = layout.GetRule() != nil
+
+	return nil
+}
+
+func (h *ruleEndHandler) OnLayoutEnd(_ string, layout UISchemaElement) error {
+	h.endRule = layout.GetRule() != nil
+
+	return nil
+}
+
+func TestParseStreamKeysAfterElements(t *testing.T) {
+	handler := &ruleEndHandler{}
+	require.NoError(t, ParseStream([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [{"type": "Control", "scope": "#/properties/name"}],
+		"rule": {"effect": "HIDE", "condition": {"type": "LEAF", "scope": "#/properties/hidden", "expectedValue": true}}
+	}`), handler))
+
+	assert.False(t, handler.startRule)
+	assert.True(t, handler.endRule)
+}
+
+// failingReader returns an error once the data before it has been read
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errReadFailed
+}
+
+var errReadFailed = errors.New("read failed")
+
+func TestParseStreamReaderIsIncremental(t *testing.T) {
+	prefix := `{"type": "VerticalLayout", "elements": [{"type": "Control", "scope": "#/properties/name"}, `
+
+	handler := &countingStreamHandler{}
+	err := ParseStreamReader(io.MultiReader(strings.NewReader(prefix), failingReader{}), handler)
+	require.ErrorIs(t, err, errReadFailed)
+
+	// Elements before the failure were delivered without the rest of the document
+	assert.Equal(t, []string{"start VerticalLayout ", "control elements[0]"}, handler.events)
+}
+
+func TestParseStreamSkipsNonCategories(t *testing.T) {
+	handler := &countingStreamHandler{}
+	require.NoError(t, ParseStream([]byte(`{
+		"type": "Categorization",
+		"elements": [
+			{"type": "Control", "scope": "#/properties/stray"},
+			{"type": "Category", "label": "Main", "elements": [{"type": "Control", "scope": "#/properties/name"}]}
+		]
+	}`), handler))
+
+	assert.Equal(t, []string{"#/properties/name"}, handler.controls)
+}
+
+// listStreamHandler records lists reported by ParseStream
+type listStreamHandler struct {
+	countingStreamHandler
+	lists []string
+}
+
+func (h *listStreamHandler) OnListWithDetail(path string, list *ListWithDetail) error {
+	h.lists = append(h.lists, list.Scope)
+	h.events = append(h.events, "list "+path)
+
+	return nil
+}
+
+func TestParseStreamListWithDetail(t *testing.T) {
+	handler := &listStreamHandler{}
+	require.NoError(t, ParseStream([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{
+				"type": "ListWithDetail",
+				"scope": "#/properties/users",
+				"options": {"detail": {"type": "Control", "scope": "#/properties/name"}}
+			}
+		]
+	}`), handler))
+
+	assert.Equal(t, []string{"#/properties/users"}, handler.lists)
+	assert.Equal(t, []string{"start VerticalLayout ", "list elements[0]", "end VerticalLayout "}, handler.events)
+}
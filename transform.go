@@ -0,0 +1,181 @@
+package jsonforms
+
+import (
+	"fmt"
+	"slices"
+)
+
+// RenameScope rewrites every control and list scope and rule condition scope equal to oldScope,
+// including conditions nested in AND/OR, and returns the number of replacements.
+// The tree is modified in place. Detail layouts are left untouched since their scopes
+// are relative to the array item schema.
+func RenameScope(root UISchemaElement, oldScope, newScope string) int {
+	count := 0
+
+	_ = WalkWithPath(root, func(element UISchemaElement, _ string) error {
+		if scope := elementScope(element); scope != nil && *scope == oldScope {
+			*scope = newScope
+			count++
+		}
+
+		forEachElementCondition(element, func(condition Condition) {
+			switch c := condition.(type) {
+			case *LeafCondition:
+				if c.Scope == oldScope {
+					c.Scope = newScope
+					count++
+				}
+			case *SchemaBasedCondition:
+				if c.Scope == oldScope {
+					c.Scope = newScope
+					count++
+				}
+			}
+		})
+
+		return nil
+	})
+
+	return count
+}
+
+// StripRules removes every rule from the tree in place, including inside control detail layouts
+func StripRules(root UISchemaElement) {
+	if root == nil {
+		return
+	}
+
+	if b, ok := root.(interface{ base() *BaseUISchemaElement }); ok {
+		b.base().Rule = nil
+		b.base().Rules = nil
+	}
+
+	for _, child := range directChildren(root) {
+		StripRules(child)
+	}
+}
+
+// NormalizeTypes rewrites the Type of every standard element to its canonical JSON Forms
+// spelling, e.g. "control" on a Control parsed with ParseOptions.CaseInsensitiveTypes becomes
+// "Control", in place. Custom element types are left untouched.
+func NormalizeTypes(root UISchemaElement) {
+	forEachElement(root, func(element UISchemaElement) {
+		canonical := canonicalType(element)
+		if canonical == "" {
+			return
+		}
+
+		if b, ok := element.(interface{ base() *BaseUISchemaElement }); ok {
+			b.base().Type = canonical
+		}
+	})
+}
+
+// canonicalType returns the JSON Forms type name of a standard element, or "" for other elements
+func canonicalType(element UISchemaElement) string {
+	switch element.(type) {
+	case *Control:
+		return "Control"
+	case *VerticalLayout:
+		return "VerticalLayout"
+	case *HorizontalLayout:
+		return "HorizontalLayout"
+	case *Group:
+		return "Group"
+	case *Categorization:
+		return "Categorization"
+	case *Category:
+		return "Category"
+	case *Label:
+		return "Label"
+	case *ListWithDetail:
+		return "ListWithDetail"
+	default:
+		return ""
+	}
+}
+
+// SplitByCategory maps the label of each Category directly inside a root Categorization to a
+// VerticalLayout holding that category's elements and rules, for rendering one tab at a time.
+// The layouts have their own element slices, so reassigning their children leaves the tree intact,
+// though the children themselves are shared. A repeated label gets a numbered key such as
+// "Details (2)" in step order. Any other root is returned as the single entry "".
+func SplitByCategory(root UISchemaElement) map[string]UISchemaElement {
+	categorization, ok := root.(*Categorization)
+	if !ok {
+		return map[string]UISchemaElement{"": root}
+	}
+
+	split := map[string]UISchemaElement{}
+
+	for _, category := range categorization.Steps() {
+		layout := NewVerticalLayout(slices.Clone(category.Elements)...)
+		layout.Rule = category.Rule
+		layout.Rules = slices.Clone(category.Rules)
+
+		key := category.Label
+		for n := 2; split[key] != nil; n++ {
+			key = fmt.Sprintf("%s (%d)", category.Label, n)
+		}
+
+		split[key] = layout
+	}
+
+	return split
+}
+
+// PruneEmpty removes layouts, groups, categories and categorizations left without elements,
+// recursively and in place, and returns the pruned root or nil when nothing remains.
+// With keepLabeled set, empty groups, categories and labeled categorizations are kept.
+// Custom elements are always kept, though their children are pruned.
+func PruneEmpty(root UISchemaElement, keepLabeled bool) UISchemaElement {
+	switch e := root.(type) {
+	case *VerticalLayout:
+		if e.Elements = pruneElements(e.Elements, keepLabeled); len(e.Elements) == 0 {
+			return nil
+		}
+	case *HorizontalLayout:
+		if e.Elements = pruneElements(e.Elements, keepLabeled); len(e.Elements) == 0 {
+			return nil
+		}
+	case *Group:
+		if e.Elements = pruneElements(e.Elements, keepLabeled); len(e.Elements) == 0 && !keepLabeled {
+			return nil
+		}
+	case *Category:
+		if e.Elements = pruneElements(e.Elements, keepLabeled); len(e.Elements) == 0 && !keepLabeled {
+			return nil
+		}
+	case *Categorization:
+		kept := make([]CategoryElement, 0, len(e.Elements))
+
+		for _, child := range e.Elements {
+			if PruneEmpty(child, keepLabeled) != nil {
+				kept = append(kept, child)
+			}
+		}
+
+		if e.Elements = kept; len(kept) == 0 && (!keepLabeled || e.Label == nil) {
+			return nil
+		}
+	case *CustomElement:
+		if e.Elements != nil {
+			e.Elements = pruneElements(e.Elements, keepLabeled)
+		}
+	}
+
+	return root
+}
+
+// pruneElements prunes each element and drops those that pruned away
+func pruneElements(elements []UISchemaElement, keepLabeled bool) []UISchemaElement {
+	kept := make([]UISchemaElement, 0, len(elements))
+
+	for _, element := range elements {
+		if pruned := PruneEmpty(element, keepLabeled); pruned != nil {
+			kept = append(kept, pruned)
+		}
+	}
+
+	return kept
+}
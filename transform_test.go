@@ -0,0 +1,279 @@
+package jsonforms
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenameScope(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{
+				"type": "Control",
+				"scope": "#/properties/fullName"
+			},
+			{
+				"type": "Control",
+				"scope": "#/properties/greeting",
+				"rule": {
+					"effect": "SHOW",
+					"condition": {
+						"type": "AND",
+						"conditions": [
+							{
+								"scope": "#/properties/fullName",
+								"schema": {"minLength": 1}
+							},
+							{
+								"type": "LEAF",
+								"scope": "#/properties/subscribe",
+								"expectedValue": true
+							}
+						]
+					}
+				}
+			}
+		]
+	}`)
+
+	result, err := Parse(uiSchema, nil)
+	require.NoError(t, err)
+
+	count := RenameScope(result.UISchema, "#/properties/fullName", "#/properties/name")
+	assert.Equal(t, 2, count)
+
+	layout, ok := result.UISchema.(*VerticalLayout)
+	require.True(t, ok, "Expected VerticalLayout, got %T", result.UISchema)
+
+	control, ok := layout.Elements[0].(*Control)
+	require.True(t, ok, "Expected Control, got %T", layout.Elements[0])
+	assert.Equal(t, "#/properties/name", control.Scope)
+
+	greeting, ok := layout.Elements[1].(*Control)
+	require.True(t, ok, "Expected Control, got %T", layout.Elements[1])
+
+	and, ok := greeting.Rule.Condition.(*AndCondition)
+	require.True(t, ok, "Expected AndCondition, got %T", greeting.Rule.Condition)
+
+	condition, ok := and.Conditions[0].(*SchemaBasedCondition)
+	require.True(t, ok, "Expected SchemaBasedCondition, got %T", and.Conditions[0])
+	assert.Equal(t, "#/properties/name", condition.Scope)
+}
+
+func TestStripRules(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "Group",
+		"label": "Contact",
+		"rule": {
+			"effect": "HIDE",
+			"condition": {"type": "LEAF", "scope": "#/properties/anonymous", "expectedValue": true}
+		},
+		"elements": [
+			{
+				"type": "Control",
+				"scope": "#/properties/email",
+				"rule": {
+					"effect": "SHOW",
+					"condition": {"type": "LEAF", "scope": "#/properties/subscribe", "expectedValue": true}
+				}
+			}
+		]
+	}`)
+
+	withRules, err := Parse(uiSchema, nil)
+	require.NoError(t, err)
+
+	group, ok := withRules.UISchema.(*Group)
+	require.True(t, ok, "Expected Group, got %T", withRules.UISchema)
+	assert.NotNil(t, group.Rule)
+	assert.NotNil(t, group.Elements[0].GetRule())
+
+	stripped, err := ParseWithOptions(uiSchema, nil, ParseOptions{StripRules: true})
+	require.NoError(t, err)
+
+	group, ok = stripped.UISchema.(*Group)
+	require.True(t, ok, "Expected Group, got %T", stripped.UISchema)
+	assert.Nil(t, group.Rule)
+	assert.Nil(t, group.Elements[0].GetRule())
+
+	StripRules(withRules.UISchema)
+
+	_ = WalkWithPath(withRules.UISchema, func(element UISchemaElement, path string) error {
+		assert.Empty(t, ElementRules(element), "Expected no rules at %q", path)
+
+		return nil
+	})
+}
+
+func TestSplitByCategory(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "Categorization",
+		"elements": [
+			{
+				"type": "Category",
+				"label": "Personal",
+				"elements": [
+					{"type": "Control", "scope": "#/properties/name"},
+					{"type": "Control", "scope": "#/properties/age"}
+				]
+			},
+			{
+				"type": "Category",
+				"label": "Contact",
+				"elements": [{"type": "Control", "scope": "#/properties/email"}]
+			}
+		]
+	}`), nil)
+	require.NoError(t, err)
+
+	split := SplitByCategory(result.UISchema)
+	require.Len(t, split, 2)
+
+	personal, ok := split["Personal"].(*VerticalLayout)
+	require.True(t, ok, "Expected VerticalLayout, got %T", split["Personal"])
+	assert.Equal(t, "VerticalLayout", personal.Type)
+	assert.Len(t, personal.Elements, 2)
+
+	contact, ok := split["Contact"].(*VerticalLayout)
+	require.True(t, ok, "Expected VerticalLayout, got %T", split["Contact"])
+	assert.Len(t, contact.Elements, 1)
+
+	control := NewControl("#/properties/name")
+	assert.Equal(t, map[string]UISchemaElement{"": control}, SplitByCategory(control))
+}
+
+func TestPruneEmpty(t *testing.T) {
+	parse := func() UISchemaElement {
+		result, err := Parse([]byte(`{
+			"type": "VerticalLayout",
+			"elements": [
+				{"type": "Control", "scope": "#/properties/name"},
+				{
+					"type": "Group",
+					"label": "Address",
+					"elements": [
+						{"type": "HorizontalLayout", "elements": []}
+					]
+				}
+			]
+		}`), nil)
+		require.NoError(t, err)
+
+		return result.UISchema
+	}
+
+	pruned := PruneEmpty(parse(), false)
+	layout, ok := pruned.(*VerticalLayout)
+	require.True(t, ok, "Expected VerticalLayout, got %T", pruned)
+	require.Len(t, layout.Elements, 1)
+	assert.IsType(t, &Control{}, layout.Elements[0])
+
+	pruned = PruneEmpty(parse(), true)
+	layout, ok = pruned.(*VerticalLayout)
+	require.True(t, ok, "Expected VerticalLayout, got %T", pruned)
+	require.Len(t, layout.Elements, 2)
+
+	group, ok := layout.Elements[1].(*Group)
+	require.True(t, ok, "Expected Group, got %T", layout.Elements[1])
+	assert.Empty(t, group.Elements)
+
+	assert.Nil(t, PruneEmpty(NewVerticalLayout(NewHorizontalLayout()), false))
+}
+
+func TestNormalizeTypes(t *testing.T) {
+	control := &Control{BaseUISchemaElement: BaseUISchemaElement{Type: "control"}, Scope: "#/properties/name"}
+	custom := &CustomElement{BaseUISchemaElement: BaseUISchemaElement{Type: "myWidget"}}
+	layout := &VerticalLayout{
+		BaseUISchemaElement: BaseUISchemaElement{Type: "verticallayout"},
+		Elements:            []UISchemaElement{control, custom},
+	}
+
+	NormalizeTypes(layout)
+
+	assert.Equal(t, "VerticalLayout", layout.GetType())
+	assert.Equal(t, "Control", control.GetType())
+	assert.Equal(t, "myWidget", custom.GetType())
+}
+
+func TestNormalizeTypesParsed(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "verticalLayout",
+		"elements": [
+			{"type": "control", "scope": "#/properties/name"},
+			{"type": "myWidget"}
+		]
+	}`)
+
+	result, err := Parse(uiSchema, nil)
+	require.NoError(t, err)
+	assert.IsType(t, &CustomElement{}, result.UISchema)
+
+	result, err = ParseWithOptions(uiSchema, nil, ParseOptions{CaseInsensitiveTypes: true})
+	require.NoError(t, err)
+
+	layout, ok := result.UISchema.(*VerticalLayout)
+	require.True(t, ok, "Expected VerticalLayout, got %T", result.UISchema)
+
+	control, ok := layout.Elements[0].(*Control)
+	require.True(t, ok, "Expected Control, got %T", layout.Elements[0])
+	assert.Equal(t, "control", control.GetType())
+
+	NormalizeTypes(layout)
+
+	assert.Equal(t, "VerticalLayout", layout.GetType())
+	assert.Equal(t, "Control", control.GetType())
+	assert.Equal(t, "myWidget", layout.Elements[1].GetType())
+}
+
+func TestRenameScopeListWithDetail(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "ListWithDetail",
+		"scope": "#/properties/users",
+		"rule": {"effect": "HIDE", "condition": {"type": "LEAF", "scope": "#/properties/users", "expectedValue": []}}
+	}`), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, RenameScope(result.UISchema, "#/properties/users", "#/properties/members"))
+
+	list, ok := result.UISchema.(*ListWithDetail)
+	require.True(t, ok, "Expected ListWithDetail, got %T", result.UISchema)
+	assert.Equal(t, "#/properties/members", list.Scope)
+}
+
+func TestSplitByCategoryIndependent(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "Categorization",
+		"elements": [
+			{
+				"type": "Category",
+				"label": "Details",
+				"elements": [{"type": "Control", "scope": "#/properties/name"}]
+			},
+			{
+				"type": "Category",
+				"label": "Details",
+				"elements": [{"type": "Control", "scope": "#/properties/email"}]
+			}
+		]
+	}`), nil)
+	require.NoError(t, err)
+
+	split := SplitByCategory(result.UISchema)
+	require.Len(t, split, 2)
+
+	first, ok := split["Details"].(*VerticalLayout)
+	require.True(t, ok, "Expected VerticalLayout, got %T", split["Details"])
+
+	second, ok := split["Details (2)"].(*VerticalLayout)
+	require.True(t, ok, "Expected VerticalLayout, got %T", split["Details (2)"])
+	assert.Equal(t, "#/properties/email", LeafControls(second)[0].Scope)
+
+	first.Elements[0] = NewLabel("Replaced")
+
+	categorization, ok := result.UISchema.(*Categorization)
+	require.True(t, ok, "Expected Categorization, got %T", result.UISchema)
+	assert.IsType(t, &Control{}, categorization.Steps()[0].Elements[0])
+}
@@ -1,9 +1,24 @@
 package jsonforms
 
+import "slices"
+
 // AST represents the complete parsed structure of a JSON Forms definition
 type AST struct {
 	UISchema UISchemaElement `json:"uischema"`
 	Schema   any             `json:"schema"` // Raw JSON Schema
+
+	// TypedSchema is the data schema decoded into JSONSchema when ParseOptions.TypedSchema is set
+	TypedSchema *JSONSchema `json:"-"`
+
+	// UISchemas is the "uischemas" registry from the UI schema root, used to pick detail layouts
+	UISchemas []ScopedUISchema `json:"uischemas,omitempty"`
+}
+
+// ScopedUISchema is a registry entry selecting a UI schema by a named tester or a scope
+type ScopedUISchema struct {
+	Tester   string          `json:"tester,omitempty"`
+	Scope    string          `json:"scope,omitempty"`
+	UISchema UISchemaElement `json:"uischema"`
 }
 
 // UISchemaElement is the base interface for all UI schema elements
@@ -18,8 +33,16 @@ type UISchemaElement interface {
 type BaseUISchemaElement struct {
 	Type    string         `json:"type"`
 	Rule    *Rule          `json:"rule,omitempty"`
+	Rules   []*Rule        `json:"-"` // All rules when a "rules" array is used; Rules[0] is Rule
 	Options map[string]any `json:"options,omitempty"`
 	I18n    *string        `json:"i18n,omitempty"`
+	Extra   map[string]any `json:"-"` // Unrecognized keys preserved for re-emission
+	Raw     map[string]any `json:"-"` // Complete raw element data when ParseOptions.PreserveRaw is set
+}
+
+// base gives package functions mutable access to the embedded base of any element
+func (b *BaseUISchemaElement) base() *BaseUISchemaElement {
+	return b
 }
 
 // GetType returns the type of the UI schema element
@@ -32,6 +55,43 @@ func (b *BaseUISchemaElement) GetRule() *Rule {
 	return b.Rule
 }
 
+// GetRules returns every rule attached to this element, falling back to Rule when Rules is unset.
+// Rule takes precedence for the first entry, so reassigning Rule replaces Rules[0].
+func (b *BaseUISchemaElement) GetRules() []*Rule {
+	if len(b.Rules) == 0 {
+		if b.Rule != nil {
+			return []*Rule{b.Rule}
+		}
+
+		return nil
+	}
+
+	if b.Rules[0] == b.Rule {
+		return b.Rules
+	}
+
+	rules := slices.Clone(b.Rules[1:])
+	if b.Rule != nil {
+		rules = slices.Insert(rules, 0, b.Rule)
+	}
+
+	return rules
+}
+
+// ElementRules returns every rule attached to an element. Elements without a GetRules method,
+// such as external UISchemaElement implementations, report their single rule.
+func ElementRules(el UISchemaElement) []*Rule {
+	if multi, ok := el.(interface{ GetRules() []*Rule }); ok {
+		return multi.GetRules()
+	}
+
+	if rule := el.GetRule(); rule != nil {
+		return []*Rule{rule}
+	}
+
+	return nil
+}
+
 // GetOptions returns the options map for this element
 func (b *BaseUISchemaElement) GetOptions() map[string]any {
 	return b.Options
@@ -45,8 +105,16 @@ func (b *BaseUISchemaElement) GetI18n() *string {
 // Control binds a UI input to a specific data property
 type Control struct {
 	BaseUISchemaElement
-	Scope string `json:"scope"`
-	Label any    `json:"label,omitempty"` // Can be string, bool, or LabelDescription
+	Scope  string          `json:"scope"`
+	Label  any             `json:"label,omitempty"` // Can be string, bool, or LabelDescription
+	Detail UISchemaElement `json:"-"`               // Parsed options.detail layout for array controls
+}
+
+// ListWithDetail shows an array as a list with a detail view of the selected item
+type ListWithDetail struct {
+	BaseUISchemaElement
+	Scope  string          `json:"scope"`
+	Detail UISchemaElement `json:"-"` // Parsed options.detail layout for the selected item
 }
 
 // LabelDescription provides detailed label configuration
@@ -104,6 +172,7 @@ func (c *Categorization) IsCategoryElement() {}
 type Label struct {
 	BaseUISchemaElement
 	Text string `json:"text"`
+	Show *bool  `json:"show,omitempty"` // Visibility hint from "show" or "options.show"
 }
 
 // CustomElement represents an unknown/custom element type that is not a standard JSON Forms element
@@ -113,6 +182,16 @@ type CustomElement struct {
 	Elements []UISchemaElement `json:"elements,omitempty"` // Child elements (recursively parsed)
 }
 
+// InvalidElement stands in for an element that failed to parse when ParseOptions.SkipInvalidElements is set
+type InvalidElement struct {
+	BaseUISchemaElement
+	Err     error          `json:"-"` // Why the element could not be parsed
+	RawData map[string]any `json:"-"` // Raw element data, nil when the element was not an object
+}
+
+// IsCategoryElement lets InvalidElement stand in for a broken category
+func (i *InvalidElement) IsCategoryElement() {}
+
 // Rule defines conditional behavior for UI elements
 type Rule struct {
 	Effect    RuleEffect `json:"effect"`
@@ -127,8 +206,21 @@ const (
 	RuleEffectSHOW    RuleEffect = "SHOW"
 	RuleEffectENABLE  RuleEffect = "ENABLE"
 	RuleEffectDISABLE RuleEffect = "DISABLE"
+
+	// RuleEffectREQUIRE is a non-standard extension making a control required while its condition holds
+	RuleEffectREQUIRE RuleEffect = "REQUIRE"
 )
 
+// IsValid reports whether the effect is one of the known rule effects
+func (e RuleEffect) IsValid() bool {
+	switch e {
+	case RuleEffectHIDE, RuleEffectSHOW, RuleEffectENABLE, RuleEffectDISABLE, RuleEffectREQUIRE:
+		return true
+	default:
+		return false
+	}
+}
+
 // Condition is the base interface for all condition types
 type Condition interface {
 	GetType() string
@@ -140,6 +232,7 @@ type SchemaBasedCondition struct {
 	Scope             string `json:"scope"`
 	Schema            any    `json:"schema"` // JSON Schema object
 	FailWhenUndefined *bool  `json:"failWhenUndefined,omitempty"`
+	Relative          bool   `json:"-"` // Scope is relative to the owning control rather than absolute
 }
 
 // GetType returns the condition type
@@ -156,6 +249,7 @@ type LeafCondition struct {
 	Type          string `json:"type"` // "LEAF"
 	Scope         string `json:"scope"`
 	ExpectedValue any    `json:"expectedValue"`
+	Relative      bool   `json:"-"` // Scope is relative to the owning control rather than absolute
 }
 
 // GetType returns the condition type
@@ -184,3 +278,15 @@ type OrCondition struct {
 func (o *OrCondition) GetType() string {
 	return o.Type
 }
+
+// CustomCondition preserves a condition with an unknown type when custom conditions are allowed
+type CustomCondition struct {
+	RawData map[string]any `json:"-"` // Complete raw condition data
+}
+
+// GetType returns the condition type
+func (c *CustomCondition) GetType() string {
+	conditionType, _ := c.RawData["type"].(string)
+
+	return conditionType
+}
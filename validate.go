@@ -0,0 +1,355 @@
+package jsonforms
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Static errors for validation findings
+var (
+	ErrUnresolvableScope        = errors.New("scope does not resolve in data schema")
+	ErrElementLabelPropNotFound = errors.New("elementLabelProp not found in array item schema")
+	ErrInvalidCategoryChild     = errors.New("Categorization may only contain Category or Categorization elements")
+	ErrInvalidI18nKey           = errors.New("i18n key does not match the required format")
+	ErrEmptyCategorization      = errors.New("Categorization contains no Category")
+	ErrOptionValueNotAllowed    = errors.New("option value is not allowed")
+	ErrUnknownElementType       = errors.New("custom element type is not allowed")
+	ErrTooManyColumns           = errors.New("HorizontalLayout has too many elements")
+	ErrRequiredValueMissing     = errors.New("required value is missing")
+	ErrValueTypeMismatch        = errors.New("value does not match schema type")
+	ErrValueLength              = errors.New("value length is out of range")
+)
+
+// PathError associates an error with the path of the UI schema element that caused it
+type PathError struct {
+	Path string
+	Err  error
+}
+
+// Error formats the error with its element path
+func (e *PathError) Error() string {
+	path := e.Path
+	if path == "" {
+		path = "root"
+	}
+
+	return fmt.Sprintf("at %s: %v", path, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *PathError) Unwrap() error {
+	return e.Err
+}
+
+// ValidateRuleScopes reports rule conditions whose scopes cannot be resolved against the data schema.
+// Nested AND/OR conditions are checked as well. Returns nil when the AST has no data schema.
+func ValidateRuleScopes(ast *AST) []error {
+	if ast == nil || ast.Schema == nil {
+		return nil
+	}
+
+	var errs []error
+
+	_ = WalkWithPath(ast.UISchema, func(element UISchemaElement, path string) error {
+		forEachElementCondition(element, func(condition Condition) {
+			scope := resolveConditionScope(element, condition)
+			if scope == "" {
+				return
+			}
+
+			if _, found := ResolveScope(ast.Schema, scope); !found {
+				errs = append(errs, &PathError{Path: path, Err: fmt.Errorf("%w: %s", ErrUnresolvableScope, scope)})
+			}
+		})
+
+		return nil
+	})
+
+	return errs
+}
+
+// ValidateElementLabelProps reports array controls whose options.elementLabelProp names a property
+// missing from the array's item schema. Returns nil when the AST has no data schema.
+func ValidateElementLabelProps(ast *AST) []error {
+	if ast == nil || ast.Schema == nil {
+		return nil
+	}
+
+	var errs []error
+
+	_ = WalkWithPath(ast.UISchema, func(element UISchemaElement, path string) error {
+		control, ok := element.(*Control)
+		if !ok {
+			return nil
+		}
+
+		prop, ok := control.ElementLabelProp()
+		if !ok {
+			return nil
+		}
+
+		arraySchema, ok := ResolveScope(ast.Schema, control.Scope)
+		if !ok {
+			return nil
+		}
+
+		items, _ := arraySchema.(map[string]any)
+		if _, found := schemaProperty(items["items"], prop); !found {
+			errs = append(errs, &PathError{Path: path, Err: fmt.Errorf("%w: %s", ErrElementLabelPropNotFound, prop)})
+		}
+
+		return nil
+	})
+
+	return errs
+}
+
+// ValidateUISchema checks raw UI schema JSON against the shapes Parse expects, such as required
+// fields per element type, rule and condition structure, and categorization children. Unlike Parse
+// it keeps going after a problem and returns every violation as a PathError.
+func ValidateUISchema(data []byte) []error {
+	var raw any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return []error{fmt.Errorf("invalid JSON: %w", err)}
+	}
+
+	var errs []error
+
+	validateRawElement(raw, "", &errs)
+
+	return errs
+}
+
+// validateRawElement parses a single element without its children to check its own fields,
+// then validates each child
+func validateRawElement(data any, path string, errs *[]error) {
+	element, ok := data.(map[string]any)
+	if !ok {
+		*errs = append(*errs, &PathError{Path: path, Err: ErrElementNotObject})
+
+		return
+	}
+
+	shallow, children := splitChildren(element)
+	if _, err := (&parser{}).parseUISchemaElement(shallow); err != nil {
+		*errs = append(*errs, &PathError{Path: path, Err: err})
+	}
+
+	elementType, _ := element["type"].(string)
+
+	for i, child := range children {
+		childAt := childPath(path, fmt.Sprintf("elements[%d]", i))
+
+		if elementType == "Categorization" {
+			childMap, _ := child.(map[string]any)
+			if childType, ok := childMap["type"].(string); ok && childType != "Category" && childType != "Categorization" {
+				*errs = append(*errs, &PathError{Path: childAt, Err: fmt.Errorf("%w: %s", ErrInvalidCategoryChild, childType)})
+			}
+		}
+
+		validateRawElement(child, childAt, errs)
+	}
+}
+
+// defaultI18nKeyPattern accepts lowercase keys separated by dots, such as "person.first_name"
+var defaultI18nKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*(\.[a-z][a-z0-9_]*)*$`)
+
+// ValidateI18nKeys reports elements whose i18n key does not match pattern, including elements in
+// detail layouts. A nil pattern uses lowercase dot-separated keys such as "person.first_name".
+func ValidateI18nKeys(root UISchemaElement, pattern *regexp.Regexp) []error {
+	if pattern == nil {
+		pattern = defaultI18nKeyPattern
+	}
+
+	var errs []error
+
+	_ = walkTree(root, "", func(element UISchemaElement, path string) error {
+		if key := element.GetI18n(); key != nil && !pattern.MatchString(*key) {
+			errs = append(errs, &PathError{Path: path, Err: fmt.Errorf("%w: %q", ErrInvalidI18nKey, *key)})
+		}
+
+		return nil
+	})
+
+	return errs
+}
+
+// ValidateCategorizations reports every Categorization without at least one Category, including
+// those in detail layouts. A nested categorization only counts towards its parent when it contains
+// a category itself.
+func ValidateCategorizations(root UISchemaElement) []error {
+	var errs []error
+
+	_ = walkTree(root, "", func(element UISchemaElement, path string) error {
+		if categorization, ok := element.(*Categorization); ok && !hasCategory(categorization) {
+			errs = append(errs, &PathError{Path: path, Err: ErrEmptyCategorization})
+		}
+
+		return nil
+	})
+
+	return errs
+}
+
+// hasCategory reports whether a categorization contains a category, directly or through nested categorizations
+func hasCategory(categorization *Categorization) bool {
+	for _, child := range categorization.Elements {
+		switch c := child.(type) {
+		case *Category:
+			return true
+		case *Categorization:
+			if hasCategory(c) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// ValidateOptionEnum reports every element whose options contain optionKey with a value outside allowed,
+// such as an unknown color token in options.bg. Elements in detail layouts are checked as well.
+func ValidateOptionEnum(root UISchemaElement, optionKey string, allowed []string) []error {
+	var errs []error
+
+	_ = walkTree(root, "", func(element UISchemaElement, path string) error {
+		value, ok := element.GetOptions()[optionKey]
+		if !ok {
+			return nil
+		}
+
+		if text, isString := value.(string); !isString || !slices.Contains(allowed, text) {
+			errs = append(errs, &PathError{Path: path, Err: fmt.Errorf("%w: %s=%v", ErrOptionValueNotAllowed, optionKey, value)})
+		}
+
+		return nil
+	})
+
+	return errs
+}
+
+// ValidateKnownTypes reports every custom element whose type is not listed in allowedCustom,
+// including custom elements in detail layouts. Standard JSON Forms elements always pass.
+func ValidateKnownTypes(root UISchemaElement, allowedCustom []string) []error {
+	var errs []error
+
+	_ = walkTree(root, "", func(element UISchemaElement, path string) error {
+		if custom, ok := element.(*CustomElement); ok && !slices.Contains(allowedCustom, custom.Type) {
+			errs = append(errs, &PathError{Path: path, Err: fmt.Errorf("%w: %s", ErrUnknownElementType, custom.Type)})
+		}
+
+		return nil
+	})
+
+	return errs
+}
+
+// ValidateMaxColumns reports every HorizontalLayout with more than maxColumns direct elements,
+// including layouts inside detail layouts
+func ValidateMaxColumns(root UISchemaElement, maxColumns int) []error {
+	var errs []error
+
+	_ = walkTree(root, "", func(element UISchemaElement, path string) error {
+		if layout, ok := element.(*HorizontalLayout); ok && len(layout.Elements) > maxColumns {
+			errs = append(errs, &PathError{
+				Path: path,
+				Err:  fmt.Errorf("%w: %d, max %d", ErrTooManyColumns, len(layout.Elements), maxColumns),
+			})
+		}
+
+		return nil
+	})
+
+	return errs
+}
+
+// conditionScope returns the scope referenced by a leaf or schema-based condition
+func conditionScope(condition Condition) (string, bool) {
+	switch c := condition.(type) {
+	case *LeafCondition:
+		return c.Scope, true
+	case *SchemaBasedCondition:
+		return c.Scope, true
+	default:
+		return "", false
+	}
+}
+
+// ValidateInstance checks form data against the controls that are currently visible: required
+// properties must be present and present values must match their schema type. Strings must also
+// satisfy minLength and maxLength, after trimming when the control sets options.trim. A control
+// is also required while one of its REQUIRE rules holds. Controls hidden by rules are skipped. Returns nil when the AST has no data schema.
+func ValidateInstance(ast *AST, data map[string]any) []error {
+	if ast == nil || ast.Schema == nil {
+		return nil
+	}
+
+	states := ComputeVisibility(ast.UISchema, data)
+
+	var errs []error
+
+	_ = WalkWithPath(ast.UISchema, func(element UISchemaElement, path string) error {
+		control, ok := element.(*Control)
+		if !ok || !states[control].Visible {
+			return nil
+		}
+
+		if err := validateControlValue(ast.Schema, control, data); err != nil {
+			errs = append(errs, &PathError{Path: path, Err: err})
+		}
+
+		return nil
+	})
+
+	return errs
+}
+
+// requiredByRule reports whether any of the control's REQUIRE rules holds for the data
+func requiredByRule(control *Control, data map[string]any) bool {
+	for _, rule := range control.GetRules() {
+		if rule.Effect != RuleEffectREQUIRE {
+			continue
+		}
+
+		if matched, err := rule.evaluateFor(control, data); err == nil && matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateControlValue checks the data a control is bound to against its schema
+func validateControlValue(schema any, control *Control, data map[string]any) error {
+	value, defined := resolveData(data, control.Scope)
+	if !defined {
+		if control.IsRequired(schema) || requiredByRule(control, data) {
+			return fmt.Errorf("%w: %s", ErrRequiredValueMissing, control.Scope)
+		}
+
+		return nil
+	}
+
+	sub, _ := ResolveScope(schema, control.Scope)
+
+	node, _ := sub.(map[string]any)
+	if schemaType, ok := node["type"]; ok && !matchesType(value, schemaType) {
+		return fmt.Errorf("%w: %s", ErrValueTypeMismatch, control.Scope)
+	}
+
+	if text, ok := value.(string); ok {
+		if control.ShouldTrim() {
+			text = strings.TrimSpace(text)
+		}
+
+		if !matchesLength(text, node) {
+			return fmt.Errorf("%w: %s", ErrValueLength, control.Scope)
+		}
+	}
+
+	return nil
+}
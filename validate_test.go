@@ -0,0 +1,611 @@
+package jsonforms
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var personSchema = []byte(`{
+	"type": "object",
+	"properties": {
+		"name": {"type": "string"},
+		"subscribe": {"type": "boolean"},
+		"email": {"type": "string"}
+	}
+}`)
+
+func TestValidateRuleScopesValid(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{
+				"type": "Control",
+				"scope": "#/properties/email",
+				"rule": {
+					"effect": "SHOW",
+					"condition": {
+						"type": "LEAF",
+						"scope": "#/properties/subscribe",
+						"expectedValue": true
+					}
+				}
+			}
+		]
+	}`)
+
+	result, err := Parse(uiSchema, personSchema)
+	require.NoError(t, err)
+
+	assert.Empty(t, ValidateRuleScopes(result))
+}
+
+func TestValidateRuleScopesTypo(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{
+				"type": "Control",
+				"scope": "#/properties/name"
+			},
+			{
+				"type": "Control",
+				"scope": "#/properties/email",
+				"rule": {
+					"effect": "SHOW",
+					"condition": {
+						"type": "OR",
+						"conditions": [
+							{
+								"type": "LEAF",
+								"scope": "#/properties/subscribe",
+								"expectedValue": true
+							},
+							{
+								"type": "LEAF",
+								"scope": "#/properties/subscrbe",
+								"expectedValue": true
+							}
+						]
+					}
+				}
+			}
+		]
+	}`)
+
+	result, err := Parse(uiSchema, personSchema)
+	require.NoError(t, err)
+
+	errs := ValidateRuleScopes(result)
+	require.Len(t, errs, 1)
+
+	var pathErr *PathError
+	require.ErrorAs(t, errs[0], &pathErr)
+	assert.Equal(t, "elements[1]", pathErr.Path)
+	require.ErrorIs(t, errs[0], ErrUnresolvableScope)
+	assert.Contains(t, errs[0].Error(), "#/properties/subscrbe")
+}
+
+var contactsSchema = []byte(`{
+	"type": "object",
+	"properties": {
+		"contacts": {
+			"type": "array",
+			"items": {
+				"type": "object",
+				"properties": {
+					"name": {"type": "string"},
+					"phone": {"type": "string"}
+				}
+			}
+		}
+	}
+}`)
+
+func TestValidateElementLabelPropExisting(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "Control",
+		"scope": "#/properties/contacts",
+		"options": {
+			"elementLabelProp": "name"
+		}
+	}`)
+
+	result, err := Parse(uiSchema, contactsSchema)
+	require.NoError(t, err)
+
+	control, ok := result.UISchema.(*Control)
+	require.True(t, ok, "Expected Control, got %T", result.UISchema)
+
+	prop, ok := control.ElementLabelProp()
+	require.True(t, ok)
+	assert.Equal(t, "name", prop)
+
+	assert.Empty(t, ValidateElementLabelProps(result))
+}
+
+func TestValidateElementLabelPropMissing(t *testing.T) {
+	uiSchema := []byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{
+				"type": "Control",
+				"scope": "#/properties/contacts",
+				"options": {
+					"elementLabelProp": "email"
+				}
+			}
+		]
+	}`)
+
+	result, err := Parse(uiSchema, contactsSchema)
+	require.NoError(t, err)
+
+	errs := ValidateElementLabelProps(result)
+	require.Len(t, errs, 1)
+	require.ErrorIs(t, errs[0], ErrElementLabelPropNotFound)
+	assert.Contains(t, errs[0].Error(), "at elements[0]")
+}
+
+func TestValidateUISchema(t *testing.T) {
+	errs := ValidateUISchema([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{"type": "Control", "scope": "#/properties/name"},
+			{"type": "Control", "label": "Email"},
+			{
+				"type": "Categorization",
+				"elements": [
+					{"type": "Category", "label": "Main", "elements": []},
+					{"type": "Control", "scope": "#/properties/age"}
+				]
+			},
+			{
+				"type": "Label",
+				"text": "Note",
+				"rule": {"effect": "HIDE"}
+			}
+		]
+	}`))
+	require.Len(t, errs, 3)
+
+	var pathErr *PathError
+
+	require.ErrorAs(t, errs[0], &pathErr)
+	assert.Equal(t, "elements[1]", pathErr.Path)
+	require.ErrorIs(t, errs[0], ErrControlMissingScope)
+
+	require.ErrorAs(t, errs[1], &pathErr)
+	assert.Equal(t, "elements[2].elements[1]", pathErr.Path)
+	require.ErrorIs(t, errs[1], ErrInvalidCategoryChild)
+
+	require.ErrorAs(t, errs[2], &pathErr)
+	assert.Equal(t, "elements[3]", pathErr.Path)
+	require.ErrorIs(t, errs[2], ErrRuleMissingCondition)
+}
+
+func TestValidateUISchemaValid(t *testing.T) {
+	assert.Empty(t, ValidateUISchema([]byte(`{
+		"type": "Group",
+		"label": "Person",
+		"elements": [{"type": "Control", "scope": "#/properties/name"}]
+	}`)))
+
+	errs := ValidateUISchema([]byte(`{"type": `))
+	assert.Len(t, errs, 1)
+}
+
+func TestValidateI18nKeys(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "Group",
+		"label": "Person",
+		"i18n": "person",
+		"elements": [
+			{"type": "Control", "scope": "#/properties/firstName", "i18n": "person.first_name"},
+			{"type": "Control", "scope": "#/properties/name", "i18n": "Person.Name"},
+			{"type": "Control", "scope": "#/properties/age"}
+		]
+	}`), nil)
+	require.NoError(t, err)
+
+	errs := ValidateI18nKeys(result.UISchema, nil)
+	require.Len(t, errs, 1)
+
+	var pathErr *PathError
+	require.ErrorAs(t, errs[0], &pathErr)
+	assert.Equal(t, "elements[1]", pathErr.Path)
+	require.ErrorIs(t, errs[0], ErrInvalidI18nKey)
+
+	assert.Empty(t, ValidateI18nKeys(result.UISchema, regexp.MustCompile(`^[A-Za-z_.]+$`)))
+}
+
+func TestValidateI18nKeysDetail(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "Control",
+		"scope": "#/properties/contacts",
+		"options": {
+			"detail": {
+				"type": "VerticalLayout",
+				"elements": [{"type": "Control", "scope": "#/properties/email", "i18n": "Contact Email"}]
+			}
+		}
+	}`), nil)
+	require.NoError(t, err)
+
+	errs := ValidateI18nKeys(result.UISchema, nil)
+	require.Len(t, errs, 1)
+
+	var pathErr *PathError
+	require.ErrorAs(t, errs[0], &pathErr)
+	assert.Equal(t, "detail.elements[0]", pathErr.Path)
+	require.ErrorIs(t, errs[0], ErrInvalidI18nKey)
+}
+
+func TestValidateCategorizations(t *testing.T) {
+	populated, err := Parse([]byte(`{
+		"type": "Categorization",
+		"elements": [
+			{
+				"type": "Categorization",
+				"elements": [{"type": "Category", "label": "Nested", "elements": []}]
+			}
+		]
+	}`), nil)
+	require.NoError(t, err)
+	assert.Empty(t, ValidateCategorizations(populated.UISchema))
+
+	empty, err := Parse([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{
+				"type": "Categorization",
+				"elements": [
+					{"type": "Categorization", "elements": []}
+				]
+			}
+		]
+	}`), nil)
+	require.NoError(t, err)
+
+	errs := ValidateCategorizations(empty.UISchema)
+	require.Len(t, errs, 2)
+
+	var pathErr *PathError
+	require.ErrorAs(t, errs[0], &pathErr)
+	assert.Equal(t, "elements[0]", pathErr.Path)
+	require.ErrorAs(t, errs[1], &pathErr)
+	assert.Equal(t, "elements[0].elements[0]", pathErr.Path)
+	require.ErrorIs(t, errs[1], ErrEmptyCategorization)
+}
+
+func TestValidateCategorizationsDetail(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "ListWithDetail",
+		"scope": "#/properties/orders",
+		"options": {"detail": {"type": "Categorization", "elements": []}}
+	}`), nil)
+	require.NoError(t, err)
+
+	errs := ValidateCategorizations(result.UISchema)
+	require.Len(t, errs, 1)
+
+	var pathErr *PathError
+	require.ErrorAs(t, errs[0], &pathErr)
+	assert.Equal(t, "detail", pathErr.Path)
+	require.ErrorIs(t, errs[0], ErrEmptyCategorization)
+}
+
+func TestValidateOptionEnum(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{"type": "Notice", "text": "Welcome", "options": {"bg": "brand-blue"}},
+			{"type": "Notice", "text": "Warning", "options": {"bg": "neon-green"}},
+			{"type": "Control", "scope": "#/properties/name"}
+		]
+	}`), nil)
+	require.NoError(t, err)
+
+	errs := ValidateOptionEnum(result.UISchema, "bg", []string{"brand-blue", "brand-red"})
+	require.Len(t, errs, 1)
+
+	var pathErr *PathError
+	require.ErrorAs(t, errs[0], &pathErr)
+	assert.Equal(t, "elements[1]", pathErr.Path)
+	require.ErrorIs(t, errs[0], ErrOptionValueNotAllowed)
+	assert.Contains(t, errs[0].Error(), "bg=neon-green")
+}
+
+func TestValidateOptionEnumDetail(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "Control",
+		"scope": "#/properties/contacts",
+		"options": {
+			"bg": "brand-blue",
+			"detail": {
+				"type": "VerticalLayout",
+				"elements": [{"type": "Notice", "text": "Primary", "options": {"bg": "neon-green"}}]
+			}
+		}
+	}`), nil)
+	require.NoError(t, err)
+
+	errs := ValidateOptionEnum(result.UISchema, "bg", []string{"brand-blue", "brand-red"})
+	require.Len(t, errs, 1)
+
+	var pathErr *PathError
+	require.ErrorAs(t, errs[0], &pathErr)
+	assert.Equal(t, "detail.elements[0]", pathErr.Path)
+	require.ErrorIs(t, errs[0], ErrOptionValueNotAllowed)
+}
+
+func TestValidateKnownTypes(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{"type": "Control", "scope": "#/properties/name"},
+			{"type": "Notice", "text": "Welcome"},
+			{"type": "Mystery"}
+		]
+	}`), nil)
+	require.NoError(t, err)
+
+	errs := ValidateKnownTypes(result.UISchema, []string{"Notice"})
+	require.Len(t, errs, 1)
+
+	var pathErr *PathError
+	require.ErrorAs(t, errs[0], &pathErr)
+	assert.Equal(t, "elements[2]", pathErr.Path)
+	require.ErrorIs(t, errs[0], ErrUnknownElementType)
+	assert.Contains(t, errs[0].Error(), "Mystery")
+
+	assert.Len(t, ValidateKnownTypes(result.UISchema, nil), 2)
+}
+
+func TestValidateKnownTypesDetail(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [{
+			"type": "Control",
+			"scope": "#/properties/contacts",
+			"options": {
+				"detail": {
+					"type": "VerticalLayout",
+					"elements": [
+						{"type": "Notice", "text": "Contact"},
+						{"type": "Mystery"}
+					]
+				}
+			}
+		}]
+	}`), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, CountByType(result.UISchema)["Mystery"])
+
+	errs := ValidateKnownTypes(result.UISchema, []string{"Notice"})
+	require.Len(t, errs, 1)
+
+	var pathErr *PathError
+	require.ErrorAs(t, errs[0], &pathErr)
+	assert.Equal(t, "elements[0].detail.elements[1]", pathErr.Path)
+	require.ErrorIs(t, errs[0], ErrUnknownElementType)
+}
+
+func TestValidateMaxColumns(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{
+				"type": "HorizontalLayout",
+				"elements": [
+					{"type": "Control", "scope": "#/properties/a"},
+					{"type": "Control", "scope": "#/properties/b"}
+				]
+			},
+			{
+				"type": "HorizontalLayout",
+				"elements": [
+					{"type": "Control", "scope": "#/properties/c"},
+					{"type": "Control", "scope": "#/properties/d"},
+					{"type": "Control", "scope": "#/properties/e"}
+				]
+			}
+		]
+	}`), nil)
+	require.NoError(t, err)
+
+	errs := ValidateMaxColumns(result.UISchema, 2)
+	require.Len(t, errs, 1)
+
+	var pathErr *PathError
+	require.ErrorAs(t, errs[0], &pathErr)
+	assert.Equal(t, "elements[1]", pathErr.Path)
+	require.ErrorIs(t, errs[0], ErrTooManyColumns)
+	assert.Contains(t, errs[0].Error(), "3, max 2")
+
+	assert.Empty(t, ValidateMaxColumns(result.UISchema, 3))
+}
+
+func TestValidateMaxColumnsDetail(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "Control",
+		"scope": "#/properties/contacts",
+		"options": {
+			"detail": {
+				"type": "HorizontalLayout",
+				"elements": [
+					{"type": "Control", "scope": "#/properties/name"},
+					{"type": "Control", "scope": "#/properties/email"},
+					{"type": "Control", "scope": "#/properties/phone"}
+				]
+			}
+		}
+	}`), nil)
+	require.NoError(t, err)
+
+	errs := ValidateMaxColumns(result.UISchema, 2)
+	require.Len(t, errs, 1)
+
+	var pathErr *PathError
+	require.ErrorAs(t, errs[0], &pathErr)
+	assert.Equal(t, "detail", pathErr.Path)
+	require.ErrorIs(t, errs[0], ErrTooManyColumns)
+}
+
+func TestValidateInstance(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{"type": "Control", "scope": "#/properties/name"},
+			{"type": "Control", "scope": "#/properties/age"},
+			{"type": "Control", "scope": "#/properties/subscribe"},
+			{
+				"type": "Control",
+				"scope": "#/properties/email",
+				"rule": {
+					"effect": "SHOW",
+					"condition": {"scope": "#/properties/subscribe", "schema": {"const": true}}
+				}
+			}
+		]
+	}`), []byte(`{
+		"type": "object",
+		"properties": {
+			"name": {"type": "string"},
+			"age": {"type": "integer"},
+			"subscribe": {"type": "boolean"},
+			"email": {"type": "string"}
+		},
+		"required": ["name", "email"]
+	}`))
+	require.NoError(t, err)
+
+	t.Run("missing required value", func(t *testing.T) {
+		errs := ValidateInstance(result, map[string]any{"age": "old", "subscribe": true})
+		require.Len(t, errs, 3)
+
+		var pathErr *PathError
+		require.ErrorAs(t, errs[0], &pathErr)
+		assert.Equal(t, "elements[0]", pathErr.Path)
+		require.ErrorIs(t, errs[0], ErrRequiredValueMissing)
+		require.ErrorIs(t, errs[1], ErrValueTypeMismatch)
+		require.ErrorIs(t, errs[2], ErrRequiredValueMissing)
+		assert.Contains(t, errs[2].Error(), "#/properties/email")
+	})
+
+	t.Run("hidden control skipped", func(t *testing.T) {
+		errs := ValidateInstance(result, map[string]any{"name": "Ada", "age": 36, "subscribe": false})
+		assert.Empty(t, errs)
+	})
+
+	assert.Nil(t, ValidateInstance(&AST{UISchema: result.UISchema}, nil))
+}
+
+func TestValidateInstanceTrim(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"properties": {
+			"code": {"type": "string", "minLength": 2, "maxLength": 4}
+		}
+	}`)
+
+	trimmed, err := Parse([]byte(`{"type": "Control", "scope": "#/properties/code", "options": {"trim": true}}`), schema)
+	require.NoError(t, err)
+
+	untrimmed, err := Parse([]byte(`{"type": "Control", "scope": "#/properties/code"}`), schema)
+	require.NoError(t, err)
+
+	data := map[string]any{"code": "  ABCD  "}
+	assert.Empty(t, ValidateInstance(trimmed, data))
+
+	errs := ValidateInstance(untrimmed, data)
+	require.Len(t, errs, 1)
+	require.ErrorIs(t, errs[0], ErrValueLength)
+
+	errs = ValidateInstance(trimmed, map[string]any{"code": " A "})
+	require.Len(t, errs, 1)
+	require.ErrorIs(t, errs[0], ErrValueLength)
+}
+
+func TestValidateInstanceRequireRule(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "VerticalLayout",
+		"elements": [
+			{"type": "Control", "scope": "#/properties/employed"},
+			{
+				"type": "Control",
+				"scope": "#/properties/employer",
+				"rule": {
+					"effect": "REQUIRE",
+					"condition": {"scope": "#/properties/employed", "schema": {"const": true}}
+				}
+			}
+		]
+	}`), []byte(`{
+		"type": "object",
+		"properties": {
+			"employed": {"type": "boolean"},
+			"employer": {"type": "string"}
+		}
+	}`))
+	require.NoError(t, err)
+
+	layout, ok := result.UISchema.(*VerticalLayout)
+	require.True(t, ok, "Expected VerticalLayout, got %T", result.UISchema)
+	assert.True(t, layout.Elements[1].GetRule().Effect.IsValid())
+
+	errs := ValidateInstance(result, map[string]any{"employed": true})
+	require.Len(t, errs, 1)
+	require.ErrorIs(t, errs[0], ErrRequiredValueMissing)
+	assert.Contains(t, errs[0].Error(), "#/properties/employer")
+
+	assert.Empty(t, ValidateInstance(result, map[string]any{"employed": false}))
+	assert.Empty(t, ValidateInstance(result, map[string]any{"employed": true, "employer": "Acme"}))
+}
+
+func TestValidateInstanceRequireRuleRelativeScope(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "Control",
+		"scope": "#/properties/address/properties/street",
+		"rule": {
+			"effect": "REQUIRE",
+			"condition": {"type": "LEAF", "scope": "../kind", "expectedValue": "home"}
+		}
+	}`), []byte(`{
+		"type": "object",
+		"properties": {
+			"address": {
+				"type": "object",
+				"properties": {
+					"kind": {"type": "string"},
+					"street": {"type": "string"}
+				}
+			}
+		}
+	}`))
+	require.NoError(t, err)
+
+	errs := ValidateInstance(result, map[string]any{"address": map[string]any{"kind": "home"}})
+	require.Len(t, errs, 1)
+	require.ErrorIs(t, errs[0], ErrRequiredValueMissing)
+
+	assert.Empty(t, ValidateInstance(result, map[string]any{"address": map[string]any{"kind": "work"}}))
+}
+
+func TestValidateRuleScopesListWithDetailRelative(t *testing.T) {
+	result, err := Parse([]byte(`{
+		"type": "ListWithDetail",
+		"scope": "#/properties/users",
+		"rule": {"effect": "HIDE", "condition": {"scope": "items", "schema": {"const": true}}}
+	}`), []byte(`{
+		"type": "object",
+		"properties": {
+			"users": {"type": "array", "items": {"type": "boolean"}}
+		}
+	}`))
+	require.NoError(t, err)
+
+	assert.Empty(t, ValidateRuleScopes(result))
+}
@@ -1,5 +1,14 @@
 package jsonforms
 
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrSkipChildren can be returned from a visit method to continue the walk without descending
+// into that element's children
+var ErrSkipChildren = errors.New("skip children")
+
 // Visitor defines the interface for visiting UI schema elements
 type Visitor interface {
 	VisitControl(*Control) error
@@ -10,9 +19,11 @@ type Visitor interface {
 	VisitCategory(*Category) error
 	VisitLabel(*Label) error
 	VisitCustomElement(*CustomElement) error
+	VisitListWithDetail(*ListWithDetail) error
 }
 
-// Walk traverses a UI schema element tree and calls the appropriate visitor methods
+// Walk traverses a UI schema element tree and calls the appropriate visitor methods.
+// A visit method returning ErrSkipChildren stops descent into that element only.
 func Walk(element UISchemaElement, visitor Visitor) error {
 	if element == nil {
 		return nil
@@ -20,10 +31,14 @@ func Walk(element UISchemaElement, visitor Visitor) error {
 
 	switch e := element.(type) {
 	case *Control:
-		return visitor.VisitControl(e)
+		if err := visitor.VisitControl(e); err != nil {
+			return skipChildren(err)
+		}
+
+		return Walk(e.Detail, visitor)
 	case *VerticalLayout:
 		if err := visitor.VisitVerticalLayout(e); err != nil {
-			return err
+			return skipChildren(err)
 		}
 
 		for _, child := range e.Elements {
@@ -33,7 +48,7 @@ func Walk(element UISchemaElement, visitor Visitor) error {
 		}
 	case *HorizontalLayout:
 		if err := visitor.VisitHorizontalLayout(e); err != nil {
-			return err
+			return skipChildren(err)
 		}
 
 		for _, child := range e.Elements {
@@ -43,7 +58,7 @@ func Walk(element UISchemaElement, visitor Visitor) error {
 		}
 	case *Group:
 		if err := visitor.VisitGroup(e); err != nil {
-			return err
+			return skipChildren(err)
 		}
 
 		for _, child := range e.Elements {
@@ -53,7 +68,7 @@ func Walk(element UISchemaElement, visitor Visitor) error {
 		}
 	case *Categorization:
 		if err := visitor.VisitCategorization(e); err != nil {
-			return err
+			return skipChildren(err)
 		}
 
 		for _, child := range e.Elements {
@@ -63,7 +78,7 @@ func Walk(element UISchemaElement, visitor Visitor) error {
 		}
 	case *Category:
 		if err := visitor.VisitCategory(e); err != nil {
-			return err
+			return skipChildren(err)
 		}
 
 		for _, child := range e.Elements {
@@ -72,10 +87,16 @@ func Walk(element UISchemaElement, visitor Visitor) error {
 			}
 		}
 	case *Label:
-		return visitor.VisitLabel(e)
+		return skipChildren(visitor.VisitLabel(e))
+	case *ListWithDetail:
+		if err := visitor.VisitListWithDetail(e); err != nil {
+			return skipChildren(err)
+		}
+
+		return Walk(e.Detail, visitor)
 	case *CustomElement:
 		if err := visitor.VisitCustomElement(e); err != nil {
-			return err
+			return skipChildren(err)
 		}
 
 		for _, child := range e.Elements {
@@ -88,6 +109,57 @@ func Walk(element UISchemaElement, visitor Visitor) error {
 	return nil
 }
 
+// WalkPostOrder traverses the same elements as Walk, but calls the visitor for every child before
+// its parent. ErrSkipChildren has no effect since children have already been visited.
+func WalkPostOrder(element UISchemaElement, visitor Visitor) error {
+	if element == nil {
+		return nil
+	}
+
+	for _, child := range directChildren(element) {
+		if err := WalkPostOrder(child, visitor); err != nil {
+			return err
+		}
+	}
+
+	return skipChildren(visit(element, visitor))
+}
+
+// visit calls the visitor method matching the element's type
+func visit(element UISchemaElement, visitor Visitor) error {
+	switch e := element.(type) {
+	case *Control:
+		return visitor.VisitControl(e)
+	case *VerticalLayout:
+		return visitor.VisitVerticalLayout(e)
+	case *HorizontalLayout:
+		return visitor.VisitHorizontalLayout(e)
+	case *Group:
+		return visitor.VisitGroup(e)
+	case *Categorization:
+		return visitor.VisitCategorization(e)
+	case *Category:
+		return visitor.VisitCategory(e)
+	case *Label:
+		return visitor.VisitLabel(e)
+	case *CustomElement:
+		return visitor.VisitCustomElement(e)
+	case *ListWithDetail:
+		return visitor.VisitListWithDetail(e)
+	default:
+		return nil
+	}
+}
+
+// skipChildren treats ErrSkipChildren as success so the walk continues with siblings
+func skipChildren(err error) error {
+	if errors.Is(err, ErrSkipChildren) {
+		return nil
+	}
+
+	return err
+}
+
 // BaseVisitor provides default no-op implementations for all visitor methods
 // This allows users to embed BaseVisitor and only override methods they care about
 type BaseVisitor struct{}
@@ -100,3 +172,253 @@ func (b *BaseVisitor) VisitCategorization(*Categorization) error     { return ni
 func (b *BaseVisitor) VisitCategory(*Category) error                 { return nil }
 func (b *BaseVisitor) VisitLabel(*Label) error                       { return nil }
 func (b *BaseVisitor) VisitCustomElement(*CustomElement) error       { return nil }
+func (b *BaseVisitor) VisitListWithDetail(*ListWithDetail) error     { return nil }
+
+// FuncVisitor adapts plain functions to the Visitor interface. Nil fields are no-ops, so only
+// the element types of interest need a function.
+type FuncVisitor struct {
+	OnControl          func(*Control) error
+	OnVerticalLayout   func(*VerticalLayout) error
+	OnHorizontalLayout func(*HorizontalLayout) error
+	OnGroup            func(*Group) error
+	OnCategorization   func(*Categorization) error
+	OnCategory         func(*Category) error
+	OnLabel            func(*Label) error
+	OnCustomElement    func(*CustomElement) error
+	OnListWithDetail   func(*ListWithDetail) error
+}
+
+// callVisit calls fn when it is set
+func callVisit[T any](fn func(T) error, element T) error {
+	if fn == nil {
+		return nil
+	}
+
+	return fn(element)
+}
+
+func (f *FuncVisitor) VisitControl(e *Control) error {
+	return callVisit(f.OnControl, e)
+}
+
+func (f *FuncVisitor) VisitVerticalLayout(e *VerticalLayout) error {
+	return callVisit(f.OnVerticalLayout, e)
+}
+
+func (f *FuncVisitor) VisitHorizontalLayout(e *HorizontalLayout) error {
+	return callVisit(f.OnHorizontalLayout, e)
+}
+
+func (f *FuncVisitor) VisitGroup(e *Group) error {
+	return callVisit(f.OnGroup, e)
+}
+
+func (f *FuncVisitor) VisitCategorization(e *Categorization) error {
+	return callVisit(f.OnCategorization, e)
+}
+
+func (f *FuncVisitor) VisitCategory(e *Category) error {
+	return callVisit(f.OnCategory, e)
+}
+
+func (f *FuncVisitor) VisitLabel(e *Label) error {
+	return callVisit(f.OnLabel, e)
+}
+
+func (f *FuncVisitor) VisitCustomElement(e *CustomElement) error {
+	return callVisit(f.OnCustomElement, e)
+}
+
+func (f *FuncVisitor) VisitListWithDetail(e *ListWithDetail) error {
+	return callVisit(f.OnListWithDetail, e)
+}
+
+// childEntry pairs a child element with its path segment relative to its parent
+type childEntry struct {
+	Segment string
+	Element UISchemaElement
+}
+
+// childEntries returns the direct children of an element along with their path segments
+func childEntries(element UISchemaElement) []childEntry {
+	var elements []UISchemaElement
+
+	switch e := element.(type) {
+	case *VerticalLayout:
+		elements = e.Elements
+	case *HorizontalLayout:
+		elements = e.Elements
+	case *Group:
+		elements = e.Elements
+	case *Categorization:
+		for _, child := range e.Elements {
+			elements = append(elements, child)
+		}
+	case *Category:
+		elements = e.Elements
+	case *CustomElement:
+		elements = e.Elements
+	}
+
+	entries := make([]childEntry, 0, len(elements))
+	for i, child := range elements {
+		entries = append(entries, childEntry{Segment: fmt.Sprintf("elements[%d]", i), Element: child})
+	}
+
+	return entries
+}
+
+// treeEntries returns an element's childEntries followed by its detail layout under a "detail" segment
+func treeEntries(element UISchemaElement) []childEntry {
+	entries := childEntries(element)
+	if detail := detailOf(element); detail != nil {
+		entries = append(entries, childEntry{Segment: "detail", Element: detail})
+	}
+
+	return entries
+}
+
+// childPath appends a segment to an element path
+func childPath(parent, segment string) string {
+	if parent == "" {
+		return segment
+	}
+
+	return parent + "." + segment
+}
+
+// WalkWithPath traverses a UI schema element tree depth-first, calling fn with each element and its path.
+// Paths are built from "elements[i]" segments joined by dots, e.g. "elements[1].elements[0]"; the root has an empty path.
+// Control and ListWithDetail detail layouts are not descended into, as their scopes are relative to the array item schema.
+func WalkWithPath(element UISchemaElement, fn func(element UISchemaElement, path string) error) error {
+	return walkWithPath(element, "", fn)
+}
+
+func walkWithPath(element UISchemaElement, path string, fn func(UISchemaElement, string) error) error {
+	if element == nil {
+		return nil
+	}
+
+	if err := fn(element, path); err != nil {
+		return err
+	}
+
+	for _, child := range childEntries(element) {
+		if err := walkWithPath(child.Element, childPath(path, child.Segment), fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// walkTree walks like WalkWithPath but also descends into detail layouts, using the same
+// "detail" path segments as WalkChecked
+func walkTree(element UISchemaElement, path string, fn func(UISchemaElement, string) error) error {
+	if element == nil {
+		return nil
+	}
+
+	if err := fn(element, path); err != nil {
+		return err
+	}
+
+	for _, child := range treeEntries(element) {
+		if err := walkTree(child.Element, childPath(path, child.Segment), fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WalkChecked visits the same elements as Walk, wrapping the first visitor error in a PathError with
+// the failing element's path, e.g. "at elements[1].elements[0]: <err>". Detail layouts appear under a
+// "detail" segment, e.g. "elements[0].detail.elements[1]". ErrSkipChildren skips an element's children as in Walk.
+func WalkChecked(element UISchemaElement, visitor Visitor) error {
+	return walkChecked(element, "", visitor)
+}
+
+func walkChecked(element UISchemaElement, path string, visitor Visitor) error {
+	if element == nil {
+		return nil
+	}
+
+	if err := visit(element, visitor); err != nil {
+		if errors.Is(err, ErrSkipChildren) {
+			return nil
+		}
+
+		return &PathError{Path: path, Err: err}
+	}
+
+	for _, child := range treeEntries(element) {
+		if err := walkChecked(child.Element, childPath(path, child.Segment), visitor); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// forEachElement calls fn for every element in document order, including control detail layouts
+func forEachElement(root UISchemaElement, fn func(UISchemaElement)) {
+	if root == nil {
+		return
+	}
+
+	fn(root)
+
+	for _, child := range directChildren(root) {
+		forEachElement(child, fn)
+	}
+}
+
+// forEachElementCondition calls fn for every condition in every rule attached to an element
+func forEachElementCondition(element UISchemaElement, fn func(Condition)) {
+	for _, rule := range ElementRules(element) {
+		forEachCondition(rule.Condition, fn)
+	}
+}
+
+// forEachCondition calls fn for a condition and, recursively, every condition nested inside AND/OR conditions
+func forEachCondition(condition Condition, fn func(Condition)) {
+	if condition == nil {
+		return
+	}
+
+	fn(condition)
+
+	switch c := condition.(type) {
+	case *AndCondition:
+		for _, child := range c.Conditions {
+			forEachCondition(child, fn)
+		}
+	case *OrCondition:
+		for _, child := range c.Conditions {
+			forEachCondition(child, fn)
+		}
+	}
+}
+
+// WalkTypes traverses the whole tree, including control detail layouts, but only calls fn
+// for elements whose GetType() is in types
+func WalkTypes(root UISchemaElement, types map[string]bool, fn func(UISchemaElement) error) error {
+	if root == nil {
+		return nil
+	}
+
+	if types[root.GetType()] {
+		if err := fn(root); err != nil {
+			return err
+		}
+	}
+
+	for _, child := range directChildren(root) {
+		if err := WalkTypes(child, types, fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}